@@ -2,19 +2,37 @@ package application
 
 import (
 	"crypto-analytics/models/constants"
-	"crypto-analytics/models/entities"
+	alertsRepo "crypto-analytics/repositories/alerts"
+	backfillRepo "crypto-analytics/repositories/backfill"
 	communityRepo "crypto-analytics/repositories/community"
+	feedSourcesRepo "crypto-analytics/repositories/feedsources"
 	historicalRepo "crypto-analytics/repositories/historical"
+	historicalFailuresRepo "crypto-analytics/repositories/historicalfailures"
+	marketIndicatorsRepo "crypto-analytics/repositories/marketindicators"
+	newsRepo "crypto-analytics/repositories/news"
+	quotesRepo "crypto-analytics/repositories/quotes"
 	telegramRepo "crypto-analytics/repositories/telegram"
+	tokenTagsRepo "crypto-analytics/repositories/tokentags"
 	trendingRepo "crypto-analytics/repositories/trending"
 	twitterRepo "crypto-analytics/repositories/twitter"
+	userTokensRepo "crypto-analytics/repositories/usertokens"
+	watchlistRepo "crypto-analytics/repositories/watchlist"
+	"crypto-analytics/services/alerts"
+	"crypto-analytics/services/analytics"
+	"crypto-analytics/services/api"
 	"crypto-analytics/services/coinmarketcap"
 	"crypto-analytics/services/cryptorank"
+	"crypto-analytics/services/feeds"
+	"crypto-analytics/services/report"
+	"crypto-analytics/services/sectors"
 	"crypto-analytics/services/telegram"
 
 	"crypto-analytics/services/twitter"
+	"crypto-analytics/services/watchlist"
 	databases "crypto-analytics/utils/databases"
+	"crypto-analytics/utils/httpclient"
 	"crypto-analytics/utils/insights"
+	"crypto-analytics/utils/metrics"
 	"time"
 
 	"github.com/go-co-op/gocron/v2"
@@ -28,59 +46,120 @@ func New() (*Impl, error) {
 		return nil, errDB
 	}
 
-	errMigration := db.GetDB().AutoMigrate(&entities.FeedSource{}, &entities.CommunityData{}, &entities.TelegramUser{}, &entities.Historical{}, &entities.TrendingCrypto{}, &entities.Tweet{})
+	errMigration := Migrate(db)
 	if errMigration != nil {
 		return nil, errMigration
 	}
 
-	probes := insights.NewProbes(db.IsConnected)
+	if errMetrics := metrics.InstrumentDB(db.GetDB()); errMetrics != nil {
+		return nil, errMetrics
+	}
+
+	probes := insights.NewProbes()
+	probes.AddCheck("database", true, insights.ReadyCheck(db.IsConnected))
 	frenchLocation, err := time.LoadLocation(constants.FrenchTimezone)
 	if err != nil {
 		return nil, err
 	}
 
-	scheduler, errScheduler := gocron.NewScheduler(gocron.WithLocation(frenchLocation))
+	scheduler, errScheduler := gocron.NewScheduler(gocron.WithLocation(frenchLocation), gocron.WithMonitorStatus(metrics.NewJobMonitor()))
 	if errScheduler != nil {
 		return nil, errScheduler
 	}
 
+	apiClient, errClient := httpclient.New(viper.GetDuration(constants.APITimeout))
+	if errClient != nil {
+		return nil, errClient
+	}
+
 	// Repositories
 	histoRepo := historicalRepo.New(db)
 	trendRepo := trendingRepo.New(db)
 	twitterRepo := twitterRepo.New(db)
 	telegramRepo := telegramRepo.New(db)
 	communityRepo := communityRepo.New(db)
-	//	feedRepo := feedsourcesRepo.New(db)
+	watchlistRepo := watchlistRepo.New(db)
+	userTokensRepo := userTokensRepo.New(db)
+	alertsRepo := alertsRepo.New(db)
+	quotesRepo := quotesRepo.New(db)
+	failuresRepo := historicalFailuresRepo.New(db)
+	backfillRepo := backfillRepo.New(db)
+	marketIndicatorsRepo := marketIndicatorsRepo.New(db)
+	tokenTagsRepo := tokenTagsRepo.New(db)
+	feedSourcesRepo := feedSourcesRepo.New(db)
+	newsRepo := newsRepo.New(db)
+
+	watchlistService, errWatchlist := watchlist.New(watchlistRepo)
+	if errWatchlist != nil {
+		return nil, errWatchlist
+	}
+
+	sectorsService, errSectors := sectors.New()
+	if errSectors != nil {
+		return nil, errSectors
+	}
 
 	twitterService, errTwitter := twitter.New(scheduler, twitterRepo, constants.GetTwitterAccounts())
 	if errTwitter != nil {
 		return nil, errTwitter
 	}
-	coinmarketcapService, errCMC := coinmarketcap.New(scheduler, trendRepo, histoRepo, communityRepo)
+	coinmarketcapService, errCMC := coinmarketcap.New(scheduler, apiClient, trendRepo, histoRepo, communityRepo, quotesRepo, failuresRepo, backfillRepo, tokenTagsRepo, watchlistService, sectorsService)
 	if errCMC != nil {
 		return nil, errCMC
 	}
 
-	cryptorankService, errCryptoRank := cryptorank.New(scheduler)
+	cryptorankService, errCryptoRank := cryptorank.New(scheduler, apiClient, marketIndicatorsRepo)
 	if errCryptoRank != nil {
 		return nil, errCryptoRank
 	}
 
-	telegramService, errTg := telegram.New(scheduler, viper.GetString(constants.TelegramBotToken), telegramRepo, coinmarketcapService, twitterService, cryptorankService)
-	if errTg != nil {
-		return nil, errTg
+	alertsService := alerts.New(alertsRepo, coinmarketcapService)
+
+	analyticsService := analytics.New(coinmarketcapService, sectorsService, watchlistService)
+
+	reportService := report.New(coinmarketcapService, twitterService, watchlistService, analyticsService)
+
+	feedClient, errFeedClient := httpclient.New(time.Duration(viper.GetInt(constants.RSSTimeout)) * time.Second)
+	if errFeedClient != nil {
+		return nil, errFeedClient
+	}
+
+	// Without reading them, the feed sources can still be managed by the admin.
+	var feedService *feeds.Impl
+	var errFeeds error
+	if viper.GetBool(constants.FeedsEnabled) {
+		feedService, errFeeds = feeds.New(feedSourcesRepo, newsRepo, userTokensRepo, watchlistService, scheduler, feedClient)
+	} else {
+		feedService, errFeeds = feeds.NewService(feedSourcesRepo, newsRepo, userTokensRepo, watchlistService, feedClient)
 	}
-	/**
-	feedService, errFeeds := feeds.New(feedRepo, scheduler)
 	if errFeeds != nil {
 		return nil, errFeeds
 	}
 
+	telegramService, errTg := telegram.New(scheduler, viper.GetString(constants.TelegramBotToken), telegramRepo, userTokensRepo, coinmarketcapService, twitterService, cryptorankService, watchlistService, alertsService, reportService, analyticsService, feedService)
+	if errTg != nil {
+		return nil, errTg
+	}
+
+	if viper.GetBool(constants.FeedsEnabled) {
+		feedService.RegisterObserver(telegramService)
+		if errFetch := feedService.FetchFeeds(); errFetch != nil {
+			log.Error().Err(errFetch).Msg("Cannot read feed sources")
+		}
+	}
 
-	feedService.RegisterObserver(telegramService)
-	feedService.FetchFeeds()
-	**/
 	coinmarketcapService.RegisterObserver(telegramService)
+	// The alerts are evaluated on each intraday quote, the personal reports show live prices too.
+	coinmarketcapService.AddQuotedSymbols(userTokensRepo.FetchSymbols)
+	coinmarketcapService.AddQuotedSymbols(alertsRepo.FetchSymbols)
+	coinmarketcapService.RegisterHealthChecks(probes)
+	cryptorankService.RegisterHealthChecks(probes)
+	twitterService.RegisterHealthChecks(probes)
+	telegramService.RegisterHealthChecks(probes)
+	probes.Handle("/backfill", insights.JSONHandler(coinmarketcapService.GetBackfillProgress))
+	probes.Handle("/metrics", metrics.Handler())
+	metrics.RegisterGauge("telegram_subscribers", "Current number of Telegram subscribers.", func() float64 { return float64(telegramRepo.Count()) })
+	api.New(histoRepo, trendRepo, communityRepo, twitterRepo, marketIndicatorsRepo, cryptorankService, watchlistService, feedService).RegisterRoutes(probes)
 	return &Impl{
 		scheduler:            scheduler,
 		probes:               probes,
@@ -88,8 +167,9 @@ func New() (*Impl, error) {
 		telegramService:      telegramService,
 		twitterService:       twitterService,
 		cryptorankService:    cryptorankService,
-		//	feedService:          feedService,
-		db: db,
+		watchlistService:     watchlistService,
+		feedService:          feedService,
+		db:                   db,
 	}, nil
 }
 
@@ -0,0 +1,73 @@
+// Package apptest wires the services against the fake API and a migrated in-memory database, for tests.
+package apptest
+
+import (
+	"crypto-analytics/application"
+	"crypto-analytics/models/constants"
+	"crypto-analytics/pkg/fakeapi"
+	backfillRepo "crypto-analytics/repositories/backfill"
+	communityRepo "crypto-analytics/repositories/community"
+	historicalRepo "crypto-analytics/repositories/historical"
+	failuresRepo "crypto-analytics/repositories/historicalfailures"
+	quotesRepo "crypto-analytics/repositories/quotes"
+	tokenTagsRepo "crypto-analytics/repositories/tokentags"
+	trendingRepo "crypto-analytics/repositories/trending"
+	watchlistRepo "crypto-analytics/repositories/watchlist"
+	cmcService "crypto-analytics/services/coinmarketcap"
+	"crypto-analytics/services/sectors"
+	"crypto-analytics/services/watchlist"
+	"crypto-analytics/utils/databases"
+	"io/fs"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+type Env struct {
+	Server    *httptest.Server
+	DB        databases.SqlConnection
+	Watchlist watchlist.Service
+	Sectors   sectors.Service
+}
+
+// New starts a fake API serving the given fixtures, the embedded ones when nil, and points the default config at it.
+// The config is reset and the database dropped once the test ends.
+func New(t *testing.T, fixtures fs.FS) *Env {
+	t.Helper()
+
+	t.Cleanup(viper.Reset)
+	for configName, defaultValue := range constants.GetDefaultConfigValues() {
+		viper.SetDefault(configName, defaultValue)
+	}
+	server := fakeapi.NewHTTPTestServer(fixtures)
+	t.Cleanup(server.Close)
+	viper.Set(constants.CoinMarketCapBaseURL, server.URL)
+	viper.Set(constants.CryptoRankBaseURL, server.URL)
+
+	db := databases.NewInMemory(t.Name())
+	if err := db.Run(); err != nil {
+		t.Fatalf("cannot open database: %v", err)
+	}
+	t.Cleanup(db.Shutdown)
+	if err := application.Migrate(db); err != nil {
+		t.Fatalf("cannot migrate database: %v", err)
+	}
+
+	watchlistService, err := watchlist.New(watchlistRepo.New(db))
+	if err != nil {
+		t.Fatalf("cannot load watchlist: %v", err)
+	}
+	sectorsService, err := sectors.New()
+	if err != nil {
+		t.Fatalf("cannot load sectors: %v", err)
+	}
+
+	return &Env{Server: server, DB: db, Watchlist: watchlistService, Sectors: sectorsService}
+}
+
+// CoinMarketCap builds the service without scheduling its jobs.
+func (env *Env) CoinMarketCap() *cmcService.Impl {
+	return cmcService.NewService(env.Server.Client(), trendingRepo.New(env.DB), historicalRepo.New(env.DB), communityRepo.New(env.DB), quotesRepo.New(env.DB),
+		failuresRepo.New(env.DB), backfillRepo.New(env.DB), tokenTagsRepo.New(env.DB), env.Watchlist, env.Sectors)
+}
@@ -0,0 +1,95 @@
+package application
+
+import (
+	"crypto-analytics/models/entities"
+	databases "crypto-analytics/utils/databases"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// Full text index of the news, an external content FTS5 table kept in sync with news_items by triggers.
+var newsIndexStatements = []string{
+	`CREATE VIRTUAL TABLE IF NOT EXISTS news_items_fts USING fts5(title, summary, categories, symbols, content='news_items', content_rowid='id', tokenize='unicode61 remove_diacritics 2')`,
+	`CREATE TRIGGER IF NOT EXISTS news_items_ai AFTER INSERT ON news_items BEGIN
+		INSERT INTO news_items_fts(rowid, title, summary, categories, symbols) VALUES (new.id, new.title, new.summary, new.categories, new.symbols);
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS news_items_ad AFTER DELETE ON news_items BEGIN
+		INSERT INTO news_items_fts(news_items_fts, rowid, title, summary, categories, symbols) VALUES ('delete', old.id, old.title, old.summary, old.categories, old.symbols);
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS news_items_au AFTER UPDATE ON news_items BEGIN
+		INSERT INTO news_items_fts(news_items_fts, rowid, title, summary, categories, symbols) VALUES ('delete', old.id, old.title, old.summary, old.categories, old.symbols);
+		INSERT INTO news_items_fts(rowid, title, summary, categories, symbols) VALUES (new.id, new.title, new.summary, new.categories, new.symbols);
+	END`,
+}
+
+// Migrate creates or updates the tables of every persisted entity.
+func Migrate(db databases.SqlConnection) error {
+	if err := db.GetDB().AutoMigrate(&entities.FeedSource{}, &entities.CommunityData{}, &entities.TelegramUser{}, &entities.Historical{}, &entities.TrendingCrypto{}, &entities.Tweet{}, &entities.WatchedToken{}, &entities.UserToken{}, &entities.PriceAlert{}, &entities.Quote{}, &entities.HistoricalFailure{}, &entities.BackfillProgress{}, &entities.MarketIndicator{}, &entities.TokenTag{}, &entities.NewsItem{}); err != nil {
+		return err
+	}
+
+	if err := migrateCommunityCounters(db.GetDB()); err != nil {
+		return err
+	}
+	if err := migrateFeedItems(db.GetDB()); err != nil {
+		return err
+	}
+	return migrateNewsIndex(db.GetDB())
+}
+
+// migrateCommunityCounters converts the followers and watch counts stored as text before they became integers.
+// SQLite keeps the values it cannot coerce, like "1,234" or "", as text once the column type changed.
+func migrateCommunityCounters(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, column := range []string{"followers", "watch_count"} {
+			result := tx.Model(&entities.CommunityData{}).
+				Where("typeof("+column+") <> 'integer'").
+				Update(column, gorm.Expr("CAST(REPLACE(COALESCE("+column+", '0'), ',', '') AS INTEGER)"))
+			if result.Error != nil {
+				return result.Error
+			}
+		}
+		return nil
+	})
+}
+
+// migrateFeedItems moves the items read before the news were stored in full, then drops their table.
+func migrateFeedItems(db *gorm.DB) error {
+	migrator := db.Migrator()
+	if !migrator.HasTable("feed_items") {
+		return nil
+	}
+
+	columns := []string{"feed_type_id", "guid", "published_at"}
+	for _, column := range []string{"source", "title", "link", "symbols"} {
+		if migrator.HasColumn("feed_items", column) {
+			columns = append(columns, column)
+		}
+	}
+	list := strings.Join(columns, ", ")
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("INSERT OR IGNORE INTO news_items (" + list + ") SELECT " + list + " FROM feed_items").Error; err != nil {
+			return err
+		}
+		return tx.Migrator().DropTable("feed_items")
+	})
+}
+
+// migrateNewsIndex creates the full text index of the news, filling it with the stored ones when new.
+func migrateNewsIndex(db *gorm.DB) error {
+	statements := newsIndexStatements
+	if !db.Migrator().HasTable("news_items_fts") {
+		statements = append(statements, `INSERT INTO news_items_fts(news_items_fts) VALUES ('rebuild')`)
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
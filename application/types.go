@@ -6,6 +6,7 @@ import (
 	"crypto-analytics/services/feeds"
 	telegramService "crypto-analytics/services/telegram"
 	"crypto-analytics/services/twitter"
+	"crypto-analytics/services/watchlist"
 	databases "crypto-analytics/utils/databases"
 	"crypto-analytics/utils/insights"
 
@@ -24,6 +25,7 @@ type Impl struct {
 	twitterService       twitter.Service
 	cryptorankService    cryptorank.Service
 	feedService          feeds.Service
+	watchlistService     watchlist.Service
 	db                   databases.SqlConnection
 	probes               insights.Probes
 }
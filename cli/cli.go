@@ -0,0 +1,296 @@
+package cli
+
+import (
+	"crypto-analytics/application"
+	"crypto-analytics/models/constants"
+	backfillRepo "crypto-analytics/repositories/backfill"
+	communityRepo "crypto-analytics/repositories/community"
+	historicalRepo "crypto-analytics/repositories/historical"
+	historicalFailuresRepo "crypto-analytics/repositories/historicalfailures"
+	quotesRepo "crypto-analytics/repositories/quotes"
+	telegramRepo "crypto-analytics/repositories/telegram"
+	tokenTagsRepo "crypto-analytics/repositories/tokentags"
+	trendingRepo "crypto-analytics/repositories/trending"
+	twitterRepo "crypto-analytics/repositories/twitter"
+	userTokensRepo "crypto-analytics/repositories/usertokens"
+	watchlistRepo "crypto-analytics/repositories/watchlist"
+	"crypto-analytics/services/analytics"
+	"crypto-analytics/services/coinmarketcap"
+	"crypto-analytics/services/report"
+	"crypto-analytics/services/sectors"
+	"crypto-analytics/services/twitter"
+	"crypto-analytics/services/watchlist"
+	"crypto-analytics/utils/databases"
+	"crypto-analytics/utils/dates"
+	"crypto-analytics/utils/httpclient"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Run executes a one-off command, e.g. `backfill --from 2024-04-19`, without
+// starting the scheduler nor the Telegram poller.
+func Run(args []string) error {
+	commands := getCommands()
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		printUsage(commands)
+		return nil
+	}
+
+	name := args[0]
+	rest := args[1:]
+	if len(rest) > 0 && !strings.HasPrefix(rest[0], "-") {
+		if _, found := commands[name+" "+rest[0]]; found {
+			name += " " + rest[0]
+			rest = rest[1:]
+		}
+	}
+
+	cmd, found := commands[name]
+	if !found {
+		printUsage(commands)
+		return fmt.Errorf("%w: %s", ErrUnknownCommand, strings.Join(args, " "))
+	}
+
+	db := databases.New()
+	if err := db.Run(); err != nil {
+		return err
+	}
+	defer db.Shutdown()
+
+	// A command writing may run on a fresh database, before the daemon ever started.
+	// The others only read, their schema is left to the daemon they may run next to.
+	if cmd.writes {
+		if err := application.Migrate(db); err != nil {
+			return err
+		}
+	}
+
+	return cmd.run(&environment{db: db, stdout: os.Stdout}, rest)
+}
+
+func getCommands() map[string]command {
+	commands := []command{
+		{name: "backfill", usage: "--from YYYY-MM-DD [--to YYYY-MM-DD] [--force]", description: "Fetch the historical listing pages missing between two days", run: backfillCmd, writes: true},
+		{name: "export", usage: "--from YYYY-MM-DD [--to YYYY-MM-DD] [--symbol SYMBOL] [--format csv|json] [--output FILE]", description: "Export stored historical data", run: exportCmd},
+		{name: "report render", usage: "[--date YYYY-MM-DD] [--symbols RLC,PHA]", description: "Print the daily report as it was for a given data day", run: reportRenderCmd},
+		{name: "db migrate", usage: "", description: "Create or update the database tables", run: dbMigrateCmd, writes: true},
+		{name: "subscribers list", usage: "", description: "List the Telegram subscribers", run: subscribersListCmd},
+		{name: "feeds list", usage: "", description: "List the feed sources", run: feedsListCmd},
+		{name: "feeds add", usage: "--id ID --url URL [--interval 1h]", description: "Add a RSS, Atom or JSON feed source", run: feedsAddCmd, writes: true},
+		{name: "feeds remove", usage: "--id ID", description: "Remove a feed source", run: feedsRemoveCmd, writes: true},
+		{name: "feeds pause", usage: "--id ID", description: "Stop reading a feed source", run: feedsPauseCmd, writes: true},
+		{name: "feeds resume", usage: "--id ID", description: "Read a paused feed source again", run: feedsResumeCmd, writes: true},
+		{name: "feeds interval", usage: "--id ID --interval 1h", description: "Change the delay between two reads of a feed source", run: feedsIntervalCmd, writes: true},
+	}
+
+	result := make(map[string]command)
+	for _, cmd := range commands {
+		result[cmd.name] = cmd
+	}
+	return result
+}
+
+func printUsage(commands map[string]command) {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	w := tabwriter.NewWriter(os.Stderr, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Usage: %s [command] [flags]\n\nWithout command, the daemon is started.\n\nCommands:\n", constants.ExternalName)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %s %s\t%s\n", name, commands[name].usage, commands[name].description)
+	}
+	w.Flush()
+}
+
+func newFlagSet(name string) *flag.FlagSet {
+	return flag.NewFlagSet(name, flag.ContinueOnError)
+}
+
+// parseDay validates a YYYY-MM-DD flag value; an empty value falls back to yesterday.
+func parseDay(name string, value string) (time.Time, error) {
+	if value == "" {
+		return time.Now().AddDate(0, 0, -1), nil
+	}
+	day, err := time.ParseInLocation(dates.DateFormat, value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --%s %q: %w", name, value, err)
+	}
+	return day, nil
+}
+
+// newWatchlistService reads the watchlist without seeding it, which is left to the daemon.
+func (env *environment) newWatchlistService() (*watchlist.Impl, error) {
+	return watchlist.NewReadOnly(watchlistRepo.New(env.db))
+}
+
+func (env *environment) newCoinmarketcapService() (*coinmarketcap.Impl, error) {
+	watchlistService, err := env.newWatchlistService()
+	if err != nil {
+		return nil, err
+	}
+
+	sectorsService, err := sectors.New()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := httpclient.New(viper.GetDuration(constants.APITimeout))
+	if err != nil {
+		return nil, err
+	}
+
+	return coinmarketcap.NewService(client,
+		trendingRepo.New(env.db),
+		historicalRepo.New(env.db),
+		communityRepo.New(env.db),
+		quotesRepo.New(env.db),
+		historicalFailuresRepo.New(env.db),
+		backfillRepo.New(env.db),
+		tokenTagsRepo.New(env.db),
+		watchlistService,
+		sectorsService), nil
+}
+
+func backfillCmd(env *environment, args []string) error {
+	flags := newFlagSet("backfill")
+	from := flags.String("from", "", "first day to backfill (YYYY-MM-DD)")
+	to := flags.String("to", "", "last day to backfill (YYYY-MM-DD), yesterday by default")
+	force := flags.Bool("force", false, "run even if another backfill is marked as running, e.g. after a crash")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *from == "" {
+		return fmt.Errorf("%w: --from", ErrMissingFlag)
+	}
+
+	fromDay, err := parseDay("from", *from)
+	if err != nil {
+		return err
+	}
+	toDay, err := parseDay("to", *to)
+	if err != nil {
+		return err
+	}
+
+	cmcService, err := env.newCoinmarketcapService()
+	if err != nil {
+		return err
+	}
+
+	progress, err := cmcService.Backfill(fromDay.Format(dates.DateFormat), toDay.Format(dates.DateFormat), *force)
+	if errors.Is(err, coinmarketcap.ErrBackfillRunning) {
+		return fmt.Errorf("%w, --force to run it anyway if it was interrupted", err)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(env.stdout, "Backfill from %s to %s: %d pages planned, %d done, %d failed\n",
+		progress.From, progress.To, progress.PlannedPages, progress.DonePages, progress.FailedPages)
+	if progress.FailedPages > 0 {
+		return fmt.Errorf("%d pages failed, they will be retried by the daemon", progress.FailedPages)
+	}
+	return nil
+}
+
+func reportRenderCmd(env *environment, args []string) error {
+	flags := newFlagSet("report render")
+	date := flags.String("date", "", "data day of the report (YYYY-MM-DD), yesterday by default")
+	symbols := flags.String("symbols", "", "comma separated symbols, the watchlist by default")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	day, err := parseDay("date", *date)
+	if err != nil {
+		return err
+	}
+
+	watchlistService, err := env.newWatchlistService()
+	if err != nil {
+		return err
+	}
+	cmcService, err := env.newCoinmarketcapService()
+	if err != nil {
+		return err
+	}
+	sectorsService, err := sectors.New()
+	if err != nil {
+		return err
+	}
+	twitterService := twitter.NewService(twitterRepo.New(env.db), constants.GetTwitterAccounts())
+	reportService := report.New(cmcService, twitterService, watchlistService, analytics.New(cmcService, sectorsService, watchlistService))
+
+	tokens := watchlistService.GetWatchlist()
+	if *symbols != "" {
+		tokens = tokens[:0:0]
+		for _, symbol := range strings.Split(*symbols, ",") {
+			symbol = strings.ToUpper(strings.TrimSpace(symbol))
+			token, found := watchlistService.FindBySymbol(symbol)
+			if !found {
+				token.Symbol, token.Desc = symbol, symbol
+				if histo, errHisto := cmcService.FetchForSymbolForDay(symbol, day.Format(dates.DateFormat)); errHisto == nil {
+					token.CryptoID = histo.ID
+					token.Desc = fmt.Sprintf("%s (%s)", histo.Name, histo.Symbol)
+				}
+			}
+			tokens = append(tokens, token)
+		}
+	}
+
+	msg, ok := reportService.RenderForDay(tokens, day)
+	fmt.Fprint(env.stdout, msg)
+	if !ok {
+		return fmt.Errorf("no data stored for %s", day.Format(dates.DateFormat))
+	}
+	return nil
+}
+
+func dbMigrateCmd(env *environment, args []string) error {
+	if err := newFlagSet("db migrate").Parse(args); err != nil {
+		return err
+	}
+	// The tables are created or updated by Run before the commands writing.
+
+	fmt.Fprintln(env.stdout, "Database is up to date")
+	return nil
+}
+
+func subscribersListCmd(env *environment, args []string) error {
+	if err := newFlagSet("subscribers list").Parse(args); err != nil {
+		return err
+	}
+
+	users, err := telegramRepo.New(env.db).FetchAll()
+	if err != nil {
+		return err
+	}
+
+	tokensRepo := userTokensRepo.New(env.db)
+	w := tabwriter.NewWriter(env.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CHAT ID\tNAME\tTOKENS")
+	for _, user := range users {
+		tokens, errTokens := tokensRepo.FetchForUser(user.ChatID)
+		if errTokens != nil {
+			return errTokens
+		}
+		symbols := make([]string, 0, len(tokens))
+		for _, token := range tokens {
+			symbols = append(symbols, token.Symbol)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\n", user.ChatID, user.Name, strings.Join(symbols, ","))
+	}
+	fmt.Fprintf(w, "\n%d subscribers\n", len(users))
+	return w.Flush()
+}
@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"crypto-analytics/models/entities"
+	historicalRepo "crypto-analytics/repositories/historical"
+	"crypto-analytics/utils/dates"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+func exportCmd(env *environment, args []string) error {
+	flags := newFlagSet("export")
+	from := flags.String("from", "", "first day to export (YYYY-MM-DD)")
+	to := flags.String("to", "", "last day to export (YYYY-MM-DD), yesterday by default")
+	symbol := flags.String("symbol", "", "only export this symbol")
+	format := flags.String("format", formatCSV, "output format: csv or json")
+	output := flags.String("output", "", "output file, stdout by default")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *from == "" {
+		return fmt.Errorf("%w: --from", ErrMissingFlag)
+	}
+	if *format != formatCSV && *format != formatJSON {
+		return fmt.Errorf("%w: %s", ErrInvalidFormat, *format)
+	}
+
+	fromDay, err := parseDay("from", *from)
+	if err != nil {
+		return err
+	}
+	toDay, err := parseDay("to", *to)
+	if err != nil {
+		return err
+	}
+
+	repo := historicalRepo.New(env.db)
+	var historicals []entities.Historical
+	if *symbol != "" {
+		historicals, err = repo.FetchForSymbolBetween(strings.ToUpper(*symbol), fromDay.Format(dates.DateFormat), toDay.Format(dates.DateFormat))
+	} else {
+		historicals, err = repo.FetchBetween(fromDay.Format(dates.DateFormat), toDay.Format(dates.DateFormat))
+	}
+	if err != nil {
+		return err
+	}
+
+	out := env.stdout
+	if *output != "" {
+		file, errFile := os.Create(*output)
+		if errFile != nil {
+			return errFile
+		}
+		defer file.Close()
+		out = file
+	}
+
+	if *format == formatJSON {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(historicals)
+	}
+	return writeHistoricalCSV(out, historicals)
+}
+
+func writeHistoricalCSV(out io.Writer, historicals []entities.Historical) error {
+	writer := csv.NewWriter(out)
+	if err := writer.Write([]string{"day", "id", "symbol", "name", "slug", "rank", "price", "market_cap", "tags"}); err != nil {
+		return err
+	}
+
+	for _, histo := range historicals {
+		record := []string{
+			histo.Day,
+			strconv.Itoa(histo.ID),
+			histo.Symbol,
+			histo.Name,
+			histo.Slug,
+			strconv.Itoa(histo.Rank),
+			strconv.FormatFloat(histo.Price, 'f', -1, 64),
+			strconv.FormatFloat(histo.Marketcap, 'f', -1, 64),
+			histo.Tags,
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
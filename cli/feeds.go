@@ -0,0 +1,148 @@
+package cli
+
+import (
+	"crypto-analytics/models/constants"
+	feedSourcesRepo "crypto-analytics/repositories/feedsources"
+	newsRepo "crypto-analytics/repositories/news"
+	userTokensRepo "crypto-analytics/repositories/usertokens"
+	"crypto-analytics/services/feeds"
+	"crypto-analytics/utils/dates"
+	"crypto-analytics/utils/httpclient"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func (env *environment) newFeedsService() (*feeds.Impl, error) {
+	watchlistService, err := env.newWatchlistService()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := httpclient.New(time.Duration(viper.GetInt(constants.RSSTimeout)) * time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	return feeds.NewService(feedSourcesRepo.New(env.db), newsRepo.New(env.db), userTokensRepo.New(env.db), watchlistService, client)
+}
+
+func feedsListCmd(env *environment, args []string) error {
+	if err := newFlagSet("feeds list").Parse(args); err != nil {
+		return err
+	}
+
+	service, err := env.newFeedsService()
+	if err != nil {
+		return err
+	}
+	sources, err := service.GetSources()
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(env.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tFORMAT\tINTERVAL\tPAUSED\tLAST READ\tURL")
+	for _, source := range sources {
+		lastFetch := "-"
+		if !source.LastFetch.IsZero() {
+			lastFetch = source.LastFetch.Format("2006-01-02 15:04")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%t\t%s\t%s\n", source.FeedTypeID, source.Format, dates.FormatDuration(service.GetInterval(source)), source.Paused, lastFetch, source.URL)
+	}
+	return w.Flush()
+}
+
+func feedsAddCmd(env *environment, args []string) error {
+	flags := newFlagSet("feeds add")
+	id := flags.String("id", "", "identifier of the source, e.g. cointelegraph")
+	url := flags.String("url", "", "URL of the RSS, Atom or JSON feed")
+	interval := flags.Duration("interval", 0, "delay between two reads, the configured default when 0")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *id == "" {
+		return fmt.Errorf("%w: --id", ErrMissingFlag)
+	}
+	if *url == "" {
+		return fmt.Errorf("%w: --url", ErrMissingFlag)
+	}
+
+	service, err := env.newFeedsService()
+	if err != nil {
+		return err
+	}
+	source, err := service.AddSource(*id, *url, *interval)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(env.stdout, "Feed source %s added (%s, every %s)\n", source.FeedTypeID, source.Format, dates.FormatDuration(service.GetInterval(source)))
+	return nil
+}
+
+func feedsRemoveCmd(env *environment, args []string) error {
+	return updateFeedSource(env, "feeds remove", args, "removed", func(service *feeds.Impl, id string) error {
+		return service.RemoveSource(id)
+	})
+}
+
+func feedsPauseCmd(env *environment, args []string) error {
+	return updateFeedSource(env, "feeds pause", args, "paused", func(service *feeds.Impl, id string) error {
+		return service.PauseSource(id, true)
+	})
+}
+
+func feedsResumeCmd(env *environment, args []string) error {
+	return updateFeedSource(env, "feeds resume", args, "resumed", func(service *feeds.Impl, id string) error {
+		return service.PauseSource(id, false)
+	})
+}
+
+func feedsIntervalCmd(env *environment, args []string) error {
+	flags := newFlagSet("feeds interval")
+	id := flags.String("id", "", "identifier of the source")
+	interval := flags.Duration("interval", 0, "delay between two reads, the configured default when 0")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *id == "" {
+		return fmt.Errorf("%w: --id", ErrMissingFlag)
+	}
+
+	service, err := env.newFeedsService()
+	if err != nil {
+		return err
+	}
+	if err := service.SetSourceInterval(*id, *interval); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(env.stdout, "Feed source %s interval updated\n", *id)
+	return nil
+}
+
+// updateFeedSource runs a change on the source given by --id.
+func updateFeedSource(env *environment, name string, args []string, done string, update func(service *feeds.Impl, id string) error) error {
+	flags := newFlagSet(name)
+	id := flags.String("id", "", "identifier of the source")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *id == "" {
+		return fmt.Errorf("%w: --id", ErrMissingFlag)
+	}
+
+	service, err := env.newFeedsService()
+	if err != nil {
+		return err
+	}
+	if err := update(service, *id); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(env.stdout, "Feed source %s %s\n", *id, done)
+	return nil
+}
@@ -0,0 +1,32 @@
+package cli
+
+import (
+	"crypto-analytics/utils/databases"
+	"errors"
+	"io"
+)
+
+const (
+	formatCSV  = "csv"
+	formatJSON = "json"
+)
+
+var (
+	ErrUnknownCommand = errors.New("unknown command")
+	ErrMissingFlag    = errors.New("missing mandatory flag")
+	ErrInvalidFormat  = errors.New("invalid export format")
+)
+
+type command struct {
+	name        string
+	usage       string
+	description string
+	run         func(env *environment, args []string) error
+	// writes migrates the database first, the other commands only read it.
+	writes bool
+}
+
+type environment struct {
+	db     databases.SqlConnection
+	stdout io.Writer
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"crypto-analytics/pkg/fakeapi"
+	"flag"
+	"io/fs"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const readHeaderTimeout = 5 * time.Second
+
+// Serves recorded CoinMarketCap and CryptoRank responses so the bot can run offline:
+// CMC_BASE_URL=http://localhost:8089 CRYPTORANK_BASE_URL=http://localhost:8089.
+func main() {
+	addr := flag.String("addr", ":8089", "listening address")
+	fixturesDir := flag.String("fixtures", "", "directory of JSON fixtures, embedded ones when empty")
+	flag.Parse()
+
+	var fixtures fs.FS
+	if *fixturesDir != "" {
+		fixtures = os.DirFS(*fixturesDir)
+	}
+
+	server := &http.Server{
+		Addr:              *addr,
+		Handler:           fakeapi.New(fixtures),
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+
+	log.Info().Str("addr", *addr).Msg("Fake CoinMarketCap/CryptoRank API is running")
+	if err := server.ListenAndServe(); err != nil {
+		log.Fatal().Err(err).Msg("Cannot serve fake API")
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"crypto-analytics/application"
+	"crypto-analytics/cli"
 	"crypto-analytics/models/constants"
 	"os"
 	"os/signal"
@@ -45,6 +46,13 @@ func initConfig() {
 }
 
 func main() {
+	if len(os.Args) > 1 {
+		if err := cli.Run(os.Args[1:]); err != nil {
+			log.Fatal().Err(err).Msgf("Command failed")
+		}
+		return
+	}
+
 	app, err := application.New()
 	if err != nil {
 		log.Fatal().Err(err).Msgf("Shutting down after failing to instantiate application")
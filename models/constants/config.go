@@ -56,39 +56,173 @@ const (
 	// Coingecko cache. Duration type.
 	CoingeckoCache = "COINGECKO_CACHE"
 
-	defaultTelegramBotToken         = ""
-	defaultTwitterAuthToken         = ""
-	defaultTwitterCSRFToken         = ""
-	defaultTwitterTweetCount        = 20
-	defaultProbePort                = 9090
-	defaultSqliteURL                = "crypto-analytics.db"
-	defaultHealthCrontab            = "* * * * *"
-	defaultTrendingCryptoCrontTab   = "0 * * * *"
-	defaultHistoricalCryptoCrontTab = "0 3 * * *"
-	defaultRedisUrl                 = "localhost:6379"
-	defaultCoingeckoCache           = 5 * time.Minute
-	defaultLogLevel                 = zerolog.InfoLevel
-	defaultProduction               = true
-	defaultUserAgent                = ExternalName
-	defaultRSSTimeout               = 60
+	// CoinMarketCap API base URL.
+	CoinMarketCapBaseURL = "CMC_BASE_URL"
+
+	// CryptoRank API base URL.
+	CryptoRankBaseURL = "CRYPTORANK_BASE_URL"
+
+	// Timeout of calls to CoinMarketCap and CryptoRank APIs. Duration type.
+	APITimeout = "API_TIMEOUT"
+
+	// Minimum delay between two calls to a same API host. Duration type.
+	APIMinInterval = "API_MIN_INTERVAL"
+
+	// Cron tab to fill the gaps of historical data since the halving.
+	HistoricalBackfillCronTab = "HISTORICAL_BACKFILL_CRON_TAB"
+
+	// Cron tab to retry failed historical fetches.
+	HistoricalRetryCronTab = "HISTORICAL_RETRY_CRON_TAB"
+
+	// HTTP cassettes for external APIs, from [off, record, replay].
+	HTTPCassetteMode = "HTTP_CASSETTE_MODE"
+
+	// Directory where HTTP cassettes are stored.
+	HTTPCassetteDir = "HTTP_CASSETTE_DIR"
+
+	// Cron tab to intraday quotes of watched tokens.
+	IntradayQuoteCronTab = "INTRADAY_QUOTE_CRON_TAB"
+
+	// How long intraday quotes are kept. Duration type.
+	IntradayQuoteRetention = "INTRADAY_QUOTE_RETENTION"
+
+	// Minimum delay between two notifications of the same price alert. Duration type.
+	AlertCooldown = "ALERT_COOLDOWN"
+
+	// Maximum age of the last trending fetch before being unhealthy. Duration type.
+	HealthTrendingMaxAge = "HEALTH_TRENDING_MAX_AGE"
+
+	// Maximum age of the last successful Twitter scrape before being degraded. Duration type.
+	HealthTwitterMaxAge = "HEALTH_TWITTER_MAX_AGE"
+
+	// Cron tab to the weekly digest of rank movers.
+	RankDigestCronTab = "RANK_DIGEST_CRON_TAB"
+
+	// Minimum market cap in USD of a token listed in the movers.
+	MoversMinMarketcap = "MOVERS_MIN_MARKETCAP"
+
+	// Maximum rank of a token listed in the movers.
+	MoversMaxRank = "MOVERS_MAX_RANK"
+
+	// Boolean; attaches a price sparkline per token to the daily report.
+	ReportSparklines = "REPORT_SPARKLINES"
+
+	// Sectors tracked from CMC tags, as a JSON array of {name, patterns}; a tag containing a pattern belongs to the sector.
+	Sectors = "SECTORS"
+
+	// Competitors of the watched tokens, as a JSON object of symbol to an array of symbols.
+	PeerGroups = "PEER_GROUPS"
+
+	// Boolean; reads the RSS feed sources and sends their news to the subscribers.
+	FeedsEnabled = "FEEDS_ENABLED"
+
+	// Cron tab to read the feed sources whose interval elapsed.
+	FeedsCronTab = "FEEDS_CRON_TAB"
+
+	// Delay between two reads of a feed source without its own interval. Duration type.
+	FeedsDefaultInterval = "FEEDS_DEFAULT_INTERVAL"
+
+	// How long the news read from the feeds are kept. Duration type.
+	NewsRetention = "NEWS_RETENTION"
+
+	// Keywords mentioning a token in the news on top of its name, symbol and handle, as a JSON object of symbol to an array of keywords.
+	NewsKeywords = "NEWS_KEYWORDS"
+
+	// Tokens watched by the bot on first start, as a JSON array of {cryptoId, symbol, gecko, handle, desc}.
+	// The watchlist is then managed with /watchlist.
+	CryptoWatchlist = "CRYPTO_WATCHLIST"
+
+	defaultTelegramBotToken          = ""
+	defaultTwitterAuthToken          = ""
+	defaultTwitterCSRFToken          = ""
+	defaultTwitterTweetCount         = 20
+	defaultProbePort                 = 9090
+	defaultSqliteURL                 = "crypto-analytics.db"
+	defaultHealthCrontab             = "* * * * *"
+	defaultTrendingCryptoCrontTab    = "0 * * * *"
+	defaultHistoricalCryptoCrontTab  = "0 3 * * *"
+	defaultRedisUrl                  = "localhost:6379"
+	defaultCoingeckoCache            = 5 * time.Minute
+	defaultLogLevel                  = zerolog.InfoLevel
+	defaultProduction                = true
+	defaultUserAgent                 = ExternalName
+	defaultRSSTimeout                = 60
+	defaultCoinMarketCapBaseURL      = "https://api.coinmarketcap.com"
+	defaultCryptoRankBaseURL         = "https://api.cryptorank.io"
+	defaultAPITimeout                = 15 * time.Second
+	defaultAPIMinInterval            = 2 * time.Second
+	defaultHistoricalBackfillCronTab = "0 4 * * *"
+	defaultHistoricalRetryCronTab    = "30 * * * *"
+	defaultHTTPCassetteMode          = "off"
+	defaultHTTPCassetteDir           = "cassettes"
+	defaultIntradayQuoteCronTab      = "*/10 * * * *"
+	defaultIntradayQuoteRetention    = 30 * 24 * time.Hour
+	defaultAlertCooldown             = 6 * time.Hour
+	defaultHealthTrendingMaxAge      = 3 * time.Hour
+	defaultHealthTwitterMaxAge       = 1 * time.Hour
+	defaultReportSparklines          = false
+	defaultRankDigestCronTab         = "0 9 * * 1"
+	defaultMoversMinMarketcap        = 10_000_000
+	defaultMoversMaxRank             = 500
+	defaultFeedsEnabled              = false
+	defaultFeedsCronTab              = "*/5 * * * *"
+	defaultFeedsDefaultInterval      = 1 * time.Hour
+	defaultNewsRetention             = 90 * 24 * time.Hour
+	defaultCryptoWatchlist           = `[
+	{"cryptoId": 1637, "symbol": "RLC", "gecko": "iexec-rlc", "handle": "IExecRLC", "desc": "iExec RLC (RLC)"},
+	{"cryptoId": 6841, "symbol": "PHA", "gecko": "pha", "handle": "PhalaNetwork", "desc": "Phala Network (PHA)"},
+	{"cryptoId": 5604, "symbol": "SCRT", "gecko": "secret", "handle": "secretnetwork", "desc": "Secret Network (SCRT)"}
+]`
+	defaultSectors = `[
+	{"name": "AI", "patterns": ["ai-", "-ai"]},
+	{"name": "DePIN", "patterns": ["depin"]},
+	{"name": "Distributed Computing", "patterns": ["distributed-computing"]}
+]`
+	defaultPeerGroups   = `{"RLC": ["GLM", "AKT", "PHA", "SCRT"]}`
+	defaultNewsKeywords = `{"RLC": ["iExec"]}`
 )
 
 func GetDefaultConfigValues() map[string]any {
 	return map[string]any{
-		TwitterAuthToken:        defaultTwitterAuthToken,
-		TwitterCSRFToken:        defaultTwitterCSRFToken,
-		TwitterTweetCount:       defaultTwitterTweetCount,
-		ProbePort:               defaultProbePort,
-		RedisURL:                defaultRedisUrl,
-		SqliteURL:               defaultSqliteURL,
-		LogLevel:                defaultLogLevel.String(),
-		Production:              defaultProduction,
-		HealthCronTab:           defaultHealthCrontab,
-		TrendingCryptoCronTab:   defaultTrendingCryptoCrontTab,
-		HistoricalCryptoCronTab: defaultHistoricalCryptoCrontTab,
-		TelegramBotToken:        defaultTelegramBotToken,
-		CoingeckoCache:          defaultCoingeckoCache,
-		UserAgent:               defaultUserAgent,
-		RSSTimeout:              defaultRSSTimeout,
+		TwitterAuthToken:          defaultTwitterAuthToken,
+		TwitterCSRFToken:          defaultTwitterCSRFToken,
+		TwitterTweetCount:         defaultTwitterTweetCount,
+		ProbePort:                 defaultProbePort,
+		RedisURL:                  defaultRedisUrl,
+		SqliteURL:                 defaultSqliteURL,
+		LogLevel:                  defaultLogLevel.String(),
+		Production:                defaultProduction,
+		HealthCronTab:             defaultHealthCrontab,
+		TrendingCryptoCronTab:     defaultTrendingCryptoCrontTab,
+		HistoricalCryptoCronTab:   defaultHistoricalCryptoCrontTab,
+		TelegramBotToken:          defaultTelegramBotToken,
+		CoingeckoCache:            defaultCoingeckoCache,
+		UserAgent:                 defaultUserAgent,
+		RSSTimeout:                defaultRSSTimeout,
+		CryptoWatchlist:           defaultCryptoWatchlist,
+		Sectors:                   defaultSectors,
+		PeerGroups:                defaultPeerGroups,
+		NewsKeywords:              defaultNewsKeywords,
+		AlertCooldown:             defaultAlertCooldown,
+		CoinMarketCapBaseURL:      defaultCoinMarketCapBaseURL,
+		CryptoRankBaseURL:         defaultCryptoRankBaseURL,
+		APITimeout:                defaultAPITimeout,
+		APIMinInterval:            defaultAPIMinInterval,
+		HistoricalRetryCronTab:    defaultHistoricalRetryCronTab,
+		HistoricalBackfillCronTab: defaultHistoricalBackfillCronTab,
+		HTTPCassetteMode:          defaultHTTPCassetteMode,
+		HTTPCassetteDir:           defaultHTTPCassetteDir,
+		IntradayQuoteCronTab:      defaultIntradayQuoteCronTab,
+		IntradayQuoteRetention:    defaultIntradayQuoteRetention,
+		HealthTrendingMaxAge:      defaultHealthTrendingMaxAge,
+		HealthTwitterMaxAge:       defaultHealthTwitterMaxAge,
+		ReportSparklines:          defaultReportSparklines,
+		RankDigestCronTab:         defaultRankDigestCronTab,
+		MoversMinMarketcap:        defaultMoversMinMarketcap,
+		MoversMaxRank:             defaultMoversMaxRank,
+		FeedsEnabled:              defaultFeedsEnabled,
+		FeedsCronTab:              defaultFeedsCronTab,
+		FeedsDefaultInterval:      defaultFeedsDefaultInterval,
+		NewsRetention:             defaultNewsRetention,
 	}
 }
@@ -0,0 +1,16 @@
+package entities
+
+import "time"
+
+type BackfillProgress struct {
+	ID           int       `json:"-" gorm:"primaryKey;autoIncrement:false"`
+	From         string    `json:"from"`
+	To           string    `json:"to"`
+	PlannedPages int       `json:"plannedPages"`
+	DonePages    int       `json:"donePages"`
+	FailedPages  int       `json:"failedPages"`
+	Running      bool      `json:"running"`
+	StartedAt    time.Time `json:"startedAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+	FinishedAt   time.Time `json:"finishedAt"`
+}
@@ -1,9 +1,9 @@
 package entities
 
 type CommunityData struct {
-	Cid        int    `gorm:"primaryKey"`
-	Day        string `gorm:"primaryKey"`
-	Symbol     string `gorm:"primaryKey"`
-	Followers  string
-	WatchCount string
+	Cid        int    `json:"cid" gorm:"primaryKey"`
+	Day        string `json:"day" gorm:"primaryKey"`
+	Symbol     string `json:"symbol" gorm:"primaryKey"`
+	Followers  int64  `json:"followers"`
+	WatchCount int64  `json:"watchCount"`
 }
@@ -6,4 +6,13 @@ type FeedSource struct {
 	FeedTypeID string `gorm:"primaryKey"`
 	URL        string
 	LastUpdate time.Time `gorm:"not null; default:current_timestamp"`
+	// Delay between two reads of the source, the configured default when zero.
+	Interval time.Duration
+	Paused   bool
+	// Format detected on the last read, from [rss, atom, json].
+	Format string
+	// Validators of the last response, sent back to only download a changed feed.
+	ETag         string
+	LastModified string
+	LastFetch    time.Time
 }
@@ -0,0 +1,11 @@
+package entities
+
+import "time"
+
+type HistoricalFailure struct {
+	Day         string `gorm:"primaryKey"`
+	Start       int    `gorm:"primaryKey;autoIncrement:false"`
+	Attempts    int
+	LastError   string
+	LastAttempt time.Time
+}
@@ -0,0 +1,21 @@
+package entities
+
+import "time"
+
+type MarketIndicator struct {
+	ID                          uint      `json:"-" gorm:"primaryKey"`
+	Timestamp                   time.Time `json:"timestamp" gorm:"index"`
+	Day                         string    `json:"day" gorm:"index"`
+	FearGreedIndex              int       `json:"fearGreedIndex"`
+	FearGreedYesterday          int       `json:"fearGreedYesterday"`
+	FearGreedLastWeek           int       `json:"fearGreedLastWeek"`
+	FearGreedLastMonth          int       `json:"fearGreedLastMonth"`
+	BtcDominance                float64   `json:"btcDominance"`
+	BtcDominanceChangePercent   float64   `json:"btcDominanceChangePercent"`
+	EthDominance                float64   `json:"ethDominance"`
+	EthDominanceChangePercent   float64   `json:"ethDominanceChangePercent"`
+	TotalMarketCap              int64     `json:"totalMarketCap"`
+	TotalMarketCapChangePercent float64   `json:"totalMarketCapChangePercent"`
+	TotalVolume24h              int64     `json:"totalVolume24h"`
+	TotalVolume24hChangePercent float64   `json:"totalVolume24hChangePercent"`
+}
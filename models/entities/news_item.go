@@ -0,0 +1,18 @@
+package entities
+
+import "time"
+
+// NewsItem is an item read from a feed source, kept to publish it only once and indexed for the full text search.
+type NewsItem struct {
+	ID         uint   `json:"id" gorm:"primaryKey"`
+	FeedTypeID string `json:"feedTypeId" gorm:"uniqueIndex:idx_news_items_guid"`
+	GUID       string `json:"guid" gorm:"uniqueIndex:idx_news_items_guid"`
+	Source     string `json:"source"`
+	Title      string `json:"title"`
+	Link       string `json:"link"`
+	Summary    string `json:"summary"`
+	// Categories of the item and symbols of the tokens it mentions, separated by ";".
+	Categories  string    `json:"categories"`
+	Symbols     string    `json:"symbols"`
+	PublishedAt time.Time `json:"publishedAt" gorm:"index"`
+}
@@ -0,0 +1,14 @@
+package entities
+
+import "time"
+
+type PriceAlert struct {
+	ID            uint      `json:"id" gorm:"primaryKey"`
+	ChatID        int64     `json:"chatId" gorm:"index"`
+	Symbol        string    `json:"symbol"`
+	Kind          string    `json:"kind"`
+	Threshold     float64   `json:"threshold"`
+	Armed         bool      `json:"armed"`
+	LastTriggered time.Time `json:"lastTriggered"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
@@ -0,0 +1,14 @@
+package entities
+
+import "time"
+
+type Quote struct {
+	ID               uint      `json:"-" gorm:"primaryKey"`
+	CryptoID         int       `json:"cryptoId" gorm:"index:idx_quote_crypto_time"`
+	Symbol           string    `json:"symbol" gorm:"index"`
+	Timestamp        time.Time `json:"timestamp" gorm:"index:idx_quote_crypto_time"`
+	Price            float64   `json:"price"`
+	Marketcap        float64   `json:"marketCap"`
+	Volume24h        float64   `json:"volume24h"`
+	PercentChange24h float64   `json:"percentChange24h"`
+}
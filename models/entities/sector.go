@@ -0,0 +1,7 @@
+package entities
+
+// Sector groups the tokens having a CMC tag containing one of the patterns.
+type Sector struct {
+	Name     string   `json:"name"`
+	Patterns []string `json:"patterns"`
+}
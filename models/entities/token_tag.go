@@ -0,0 +1,8 @@
+package entities
+
+// TokenTag links the listing of a token at a day, keyed by CMC slug and day like Historical, to one of its CMC tags.
+type TokenTag struct {
+	Slug string `json:"slug" gorm:"primaryKey"`
+	Day  string `json:"day" gorm:"primaryKey"`
+	Tag  string `json:"tag" gorm:"primaryKey;index"`
+}
@@ -1,9 +1,13 @@
 package entities
 
+import "time"
+
 type TrendingCrypto struct {
 	ID     int    `json:"id,omitempty"`
 	Slug   string `json:"slug,omitempty" gorm:"primaryKey"`
 	Day    string `json:"day,omitempty" gorm:"primaryKey"`
 	Symbol string `json:"symbol,omitempty"`
 	Name   string `json:"name,omitempty"`
+	// UpdatedAt is set on each save, telling when the trending listing was last fetched.
+	UpdatedAt time.Time `json:"-"`
 }
@@ -1,22 +1,22 @@
 package entities
 
 type Tweet struct {
-	ConversationID string
-	HTML           string
-	ID             string `gorm:"primaryKey"`
-	IsQuoted       bool
-	IsPin          bool
-	IsReply        bool
-	IsRetweet      bool
-	IsSelfThread   bool
-	Likes          int
-	Name           string
-	Mentions       int
-	PermanentURL   string
-	Replies        int
-	Retweets       int
-	Text           string
-	Timestamp      int64
-	UserID         string
-	Views          int
+	ConversationID string `json:"conversationId,omitempty"`
+	HTML           string `json:"html,omitempty"`
+	ID             string `json:"id" gorm:"primaryKey"`
+	IsQuoted       bool   `json:"isQuoted"`
+	IsPin          bool   `json:"isPin"`
+	IsReply        bool   `json:"isReply"`
+	IsRetweet      bool   `json:"isRetweet"`
+	IsSelfThread   bool   `json:"isSelfThread"`
+	Likes          int    `json:"likes"`
+	Name           string `json:"name,omitempty"`
+	Mentions       int    `json:"mentions"`
+	PermanentURL   string `json:"permanentUrl,omitempty"`
+	Replies        int    `json:"replies"`
+	Retweets       int    `json:"retweets"`
+	Text           string `json:"text,omitempty"`
+	Timestamp      int64  `json:"timestamp"`
+	UserID         string `json:"userId,omitempty"`
+	Views          int    `json:"views"`
 }
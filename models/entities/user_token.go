@@ -0,0 +1,6 @@
+package entities
+
+type UserToken struct {
+	ChatID int64  `json:"chatId" gorm:"primaryKey"`
+	Symbol string `json:"symbol" gorm:"primaryKey"`
+}
@@ -0,0 +1,9 @@
+package entities
+
+type WatchedToken struct {
+	CryptoID int    `json:"cryptoId" gorm:"primaryKey"`
+	Symbol   string `json:"symbol"`
+	Gecko    string `json:"gecko,omitempty"`
+	Handle   string `json:"handle,omitempty"`
+	Desc     string `json:"desc,omitempty"`
+}
@@ -0,0 +1,136 @@
+package cassette
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// New wraps next (http.DefaultTransport when nil) in a record/replay transport.
+func New(mode Mode, dir string, next http.RoundTripper) (*Transport, error) {
+	if mode != ModeOff && mode != ModeRecord && mode != ModeReplay {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownMode, mode)
+	}
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &Transport{mode: mode, dir: dir, next: next}, nil
+}
+
+func (transport *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if transport.mode == ModeOff {
+		return transport.next.RoundTrip(req)
+	}
+
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	path := transport.pathFor(req, body)
+
+	if transport.mode == ModeReplay {
+		return replay(req, path)
+	}
+
+	resp, err := transport.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return record(req, body, resp, path)
+}
+
+// pathFor names a cassette after the host and a hash of method, URL and body,
+// so identical requests always map to the same file.
+func (transport *Transport) pathFor(req *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(req.Method + " " + req.URL.String() + "\n"))
+	hash.Write(body)
+	name := strings.ToLower(req.Method) + "_" + hex.EncodeToString(hash.Sum(nil))[:16] + cassetteExtension
+
+	return filepath.Join(transport.dir, req.URL.Hostname(), name)
+}
+
+func replay(req *http.Request, path string) (*http.Response, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s %s", ErrCassetteMissing, req.Method, req.URL)
+	}
+
+	var cassette Cassette
+	if errJSON := json.Unmarshal(content, &cassette); errJSON != nil {
+		return nil, fmt.Errorf("failed to parse cassette %s: %w", path, errJSON)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", cassette.Response.StatusCode, http.StatusText(cassette.Response.StatusCode)),
+		StatusCode:    cassette.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        cassette.Response.Header,
+		Body:          io.NopCloser(strings.NewReader(cassette.Response.Body)),
+		ContentLength: int64(len(cassette.Response.Body)),
+		Request:       req,
+	}, nil
+}
+
+func record(req *http.Request, body []byte, resp *http.Response, path string) (*http.Response, error) {
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	cassette := Cassette{
+		Request:  RecordedRequest{Method: req.Method, URL: req.URL.String(), Body: string(body)},
+		Response: RecordedResponse{StatusCode: resp.StatusCode, Header: redactHeader(resp.Header), Body: string(respBody)},
+	}
+	content, err := json.MarshalIndent(cassette, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode cassette: %w", err)
+	}
+
+	if errDir := os.MkdirAll(filepath.Dir(path), cassetteDirPerm); errDir != nil {
+		return nil, fmt.Errorf("failed to create cassette directory: %w", errDir)
+	}
+	if errWrite := os.WriteFile(path, content, cassetteFilePerm); errWrite != nil {
+		return nil, fmt.Errorf("failed to write cassette: %w", errWrite)
+	}
+
+	return resp, nil
+}
+
+// redactHeader copies a header without the credentials it may carry, not to write them to disk.
+func redactHeader(header http.Header) http.Header {
+	redacted := header.Clone()
+	for _, name := range sensitiveHeaders {
+		redacted.Del(name)
+	}
+	return redacted
+}
+
+// readRequestBody consumes the request body and puts back a fresh reader.
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request: %w", err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	return body, nil
+}
@@ -0,0 +1,53 @@
+package cassette
+
+import (
+	"errors"
+	"net/http"
+)
+
+type Mode string
+
+const (
+	// ModeOff lets requests go through untouched.
+	ModeOff Mode = "off"
+	// ModeRecord performs real requests and stores every response on disk.
+	ModeRecord Mode = "record"
+	// ModeReplay only answers from disk, without any network access.
+	ModeReplay Mode = "replay"
+
+	cassetteExtension = ".json"
+	cassetteFilePerm  = 0o600
+	cassetteDirPerm   = 0o750
+)
+
+// Headers never written to a cassette.
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+var (
+	ErrUnknownMode     = errors.New("unknown cassette mode")
+	ErrCassetteMissing = errors.New("no cassette recorded for this request")
+)
+
+// Transport records or replays HTTP exchanges as JSON files under dir.
+type Transport struct {
+	mode Mode
+	dir  string
+	next http.RoundTripper
+}
+
+type Cassette struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+type RecordedRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+type RecordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header"`
+	Body       string      `json:"body"`
+}
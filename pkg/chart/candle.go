@@ -0,0 +1,84 @@
+package chart
+
+import (
+	"image"
+	"image/png"
+	"io"
+	"math"
+	"time"
+)
+
+// GroupCandles aggregates consecutive points by packets of size, e.g. 7 daily closes into a weekly candle.
+// A candle opens at the close of the previous one, so that daily closes give daily candles.
+func GroupCandles(points []Point, size int) []Candle {
+	if size < 1 {
+		size = 1
+	}
+
+	candles := make([]Candle, 0, len(points)/size+1)
+	for i := 0; i < len(points); i += size {
+		group := points[i:min(i+size, len(points))]
+		open := group[0].Value
+		if i > 0 {
+			open = points[i-1].Value
+		}
+		candle := Candle{Time: group[0].Time, Open: open, High: open, Low: open, Close: group[len(group)-1].Value}
+		for _, point := range group {
+			candle.High = math.Max(candle.High, point.Value)
+			candle.Low = math.Min(candle.Low, point.Value)
+		}
+		candles = append(candles, candle)
+	}
+
+	return candles
+}
+
+// Render draws the chart as a PNG image.
+func (chart CandlestickChart) Render(w io.Writer) error {
+	if len(chart.Candles) < 2 {
+		return ErrNotEnoughPoints
+	}
+
+	width, height := chart.Width, chart.Height
+	if width == 0 || height == 0 {
+		width, height = defaultWidth, defaultHeight
+	}
+
+	first, last := chart.Candles[0].Time, chart.Candles[len(chart.Candles)-1].Time
+	if !last.After(first) {
+		return ErrNotEnoughPoints
+	}
+
+	minValue, maxValue := math.Inf(1), math.Inf(-1)
+	for _, candle := range chart.Candles {
+		minValue = math.Min(minValue, candle.Low)
+		maxValue = math.Max(maxValue, candle.High)
+	}
+	minValue, maxValue = pad(minValue, maxValue)
+
+	// Half a slot on each side keeps the first and last candles inside the plot.
+	slot := last.Sub(first) / time.Duration(len(chart.Candles)-1)
+	start, end := first.Add(-slot/2), last.Add(slot/2)
+
+	img := newCanvas(width, height)
+	plotArea := image.Rect(marginLeft, marginTop, width-marginRight, height-marginBottom)
+	scale := newScale(plotArea, start, end, minValue, maxValue, false)
+	drawAxes(img, plotArea, scale, start, end, minValue, maxValue, chart.FormatValue)
+
+	bodyWidth := max(1, int(float64(plotArea.Dx())/float64(len(chart.Candles))*candleBodyRatio))
+	for _, candle := range chart.Candles {
+		c := Green
+		if candle.Close < candle.Open {
+			c = Red
+		}
+		x := scale.x(candle.Time)
+		drawLine(img, x, scale.y(candle.High), x, scale.y(candle.Low), 1, c)
+
+		top, bottom := scale.y(math.Max(candle.Open, candle.Close)), scale.y(math.Min(candle.Open, candle.Close))
+		fillRect(img, image.Rect(x-bodyWidth/2, top, x-bodyWidth/2+bodyWidth, bottom+1), c)
+	}
+
+	drawText(img, marginLeft, marginTop-14, chart.Title, Foreground)
+
+	return png.Encode(w, img)
+}
@@ -0,0 +1,82 @@
+package chart
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+func newCanvas(width int, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: Background}, image.Point{}, draw.Src)
+	return img
+}
+
+func fillRect(img *image.RGBA, rect image.Rectangle, c color.Color) {
+	draw.Draw(img, rect.Intersect(img.Bounds()), &image.Uniform{C: c}, image.Point{}, draw.Over)
+}
+
+// drawLine draws a segment with Bresenham's algorithm and a square brush.
+func drawLine(img *image.RGBA, x0, y0, x1, y1 int, thickness int, c color.Color) {
+	dx := abs(x1 - x0)
+	dy := -abs(y1 - y0)
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+
+	err := dx + dy
+	for {
+		plot(img, x0, y0, thickness, c)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y0 += sy
+		}
+	}
+}
+
+func plot(img *image.RGBA, x, y int, thickness int, c color.Color) {
+	if thickness <= 1 {
+		img.Set(x, y, c)
+		return
+	}
+	half := thickness / 2
+	fillRect(img, image.Rect(x-half, y-half, x-half+thickness, y-half+thickness), c)
+}
+
+// drawText writes s with its baseline at y, starting at x.
+func drawText(img *image.RGBA, x, y int, s string, c color.Color) {
+	drawer := &font.Drawer{
+		Dst:  img,
+		Src:  &image.Uniform{C: c},
+		Face: basicfont.Face7x13,
+		Dot:  fixed.P(x, y),
+	}
+	drawer.DrawString(s)
+}
+
+func textWidth(s string) int {
+	return font.MeasureString(basicfont.Face7x13, s).Round()
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
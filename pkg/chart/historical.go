@@ -0,0 +1,40 @@
+package chart
+
+import (
+	"crypto-analytics/models/entities"
+	"crypto-analytics/utils/dates"
+	"time"
+)
+
+// PricePoints maps daily snapshots to price points, skipping unparsable days.
+func PricePoints(historicals []entities.Historical) []Point {
+	return historicalPoints(historicals, func(historical entities.Historical) float64 { return historical.Price })
+}
+
+// RankPoints maps daily snapshots to ranking points, skipping unranked days.
+func RankPoints(historicals []entities.Historical) []Point {
+	ranked := make([]entities.Historical, 0, len(historicals))
+	for _, historical := range historicals {
+		if historical.Rank > 0 {
+			ranked = append(ranked, historical)
+		}
+	}
+	return historicalPoints(ranked, func(historical entities.Historical) float64 { return float64(historical.Rank) })
+}
+
+// MarketcapPoints maps daily snapshots to market cap points, skipping unparsable days.
+func MarketcapPoints(historicals []entities.Historical) []Point {
+	return historicalPoints(historicals, func(historical entities.Historical) float64 { return historical.Marketcap })
+}
+
+func historicalPoints(historicals []entities.Historical, value func(entities.Historical) float64) []Point {
+	points := make([]Point, 0, len(historicals))
+	for _, historical := range historicals {
+		day, err := time.ParseInLocation(dates.DateFormat, historical.Day, time.Local)
+		if err != nil {
+			continue
+		}
+		points = append(points, Point{Time: day, Value: value(historical)})
+	}
+	return points
+}
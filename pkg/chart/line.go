@@ -0,0 +1,152 @@
+package chart
+
+import (
+	"image"
+	"image/png"
+	"io"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Render draws the chart as a PNG image.
+func (chart LineChart) Render(w io.Writer) error {
+	width, height := chart.Width, chart.Height
+	if width == 0 || height == 0 {
+		width, height = defaultWidth, defaultHeight
+	}
+
+	start, end, minValue, maxValue, ok := chart.bounds()
+	if !ok {
+		return ErrNotEnoughPoints
+	}
+
+	img := newCanvas(width, height)
+	plotArea := image.Rect(marginLeft, marginTop, width-marginRight, height-marginBottom)
+	scale := newScale(plotArea, start, end, minValue, maxValue, chart.InvertY)
+
+	for _, band := range chart.Bands {
+		top := scale.y(math.Min(band.To, maxValue))
+		bottom := scale.y(math.Max(band.From, minValue))
+		fillRect(img, image.Rect(plotArea.Min.X, top, plotArea.Max.X, bottom), band.Color)
+	}
+
+	drawAxes(img, plotArea, scale, start, end, minValue, maxValue, chart.FormatValue)
+
+	for _, series := range chart.Series {
+		for i := 1; i < len(series.Points); i++ {
+			previous, current := series.Points[i-1], series.Points[i]
+			drawLine(img, scale.x(previous.Time), scale.y(previous.Value), scale.x(current.Time), scale.y(current.Value), lineThickness, series.Color)
+		}
+	}
+
+	drawText(img, marginLeft, marginTop-14, chart.Title, Foreground)
+	chart.drawLegend(img, plotArea)
+
+	return png.Encode(w, img)
+}
+
+func (chart LineChart) bounds() (time.Time, time.Time, float64, float64, bool) {
+	var start, end time.Time
+	minValue, maxValue := math.Inf(1), math.Inf(-1)
+	count := 0
+	for _, series := range chart.Series {
+		for _, point := range series.Points {
+			if count == 0 || point.Time.Before(start) {
+				start = point.Time
+			}
+			if count == 0 || point.Time.After(end) {
+				end = point.Time
+			}
+			minValue = math.Min(minValue, point.Value)
+			maxValue = math.Max(maxValue, point.Value)
+			count++
+		}
+	}
+	if count < 2 || !end.After(start) {
+		return start, end, 0, 0, false
+	}
+
+	if chart.YMin != 0 || chart.YMax != 0 {
+		return start, end, chart.YMin, chart.YMax, true
+	}
+
+	minValue, maxValue = pad(minValue, maxValue)
+	return start, end, minValue, maxValue, true
+}
+
+// pad leaves some room above and below the curves.
+func pad(minValue float64, maxValue float64) (float64, float64) {
+	padding := (maxValue - minValue) * 0.05
+	if padding == 0 {
+		padding = math.Max(math.Abs(maxValue)*0.05, 1)
+	}
+	return minValue - padding, maxValue + padding
+}
+
+func drawAxes(img *image.RGBA, plotArea image.Rectangle, scale scale, start, end time.Time, minValue, maxValue float64, format func(value float64) string) {
+	if format == nil {
+		format = func(value float64) string { return strconv.FormatFloat(value, 'f', 2, 64) }
+	}
+
+	for i := 0; i <= yTicks; i++ {
+		value := minValue + (maxValue-minValue)*float64(i)/yTicks
+		y := scale.y(value)
+		drawLine(img, plotArea.Min.X, y, plotArea.Max.X, y, 1, Grid)
+		label := format(value)
+		drawText(img, plotArea.Min.X-textWidth(label)-6, y+4, label, Foreground)
+	}
+
+	drawLine(img, plotArea.Min.X, plotArea.Min.Y, plotArea.Min.X, plotArea.Max.Y, 1, Foreground)
+	drawLine(img, plotArea.Min.X, plotArea.Max.Y, plotArea.Max.X, plotArea.Max.Y, 1, Foreground)
+
+	layout := "Jan 02"
+	if end.Sub(start) > 365*24*time.Hour {
+		layout = "Jan 2006"
+	}
+	for _, t := range []time.Time{start, start.Add(end.Sub(start) / 2), end} {
+		label := t.Format(layout)
+		x := scale.x(t) - textWidth(label)/2
+		x = max(0, min(x, img.Bounds().Dx()-textWidth(label)))
+		drawText(img, x, plotArea.Max.Y+18, label, Foreground)
+	}
+}
+
+func (chart LineChart) drawLegend(img *image.RGBA, plotArea image.Rectangle) {
+	if len(chart.Series) < 2 {
+		return
+	}
+	x := plotArea.Max.X
+	for i := len(chart.Series) - 1; i >= 0; i-- {
+		series := chart.Series[i]
+		x -= textWidth(series.Name) + 24
+		fillRect(img, image.Rect(x, marginTop-22, x+12, marginTop-12), series.Color)
+		drawText(img, x+16, marginTop-13, series.Name, Foreground)
+	}
+}
+
+type scale struct {
+	area     image.Rectangle
+	start    time.Time
+	duration time.Duration
+	minValue float64
+	maxValue float64
+	invert   bool
+}
+
+func newScale(area image.Rectangle, start, end time.Time, minValue, maxValue float64, invert bool) scale {
+	return scale{area: area, start: start, duration: end.Sub(start), minValue: minValue, maxValue: maxValue, invert: invert}
+}
+
+func (s scale) x(t time.Time) int {
+	ratio := float64(t.Sub(s.start)) / float64(s.duration)
+	return s.area.Min.X + int(math.Round(ratio*float64(s.area.Dx())))
+}
+
+func (s scale) y(value float64) int {
+	ratio := (value - s.minValue) / (s.maxValue - s.minValue)
+	if s.invert {
+		ratio = 1 - ratio
+	}
+	return s.area.Max.Y - int(math.Round(ratio*float64(s.area.Dy())))
+}
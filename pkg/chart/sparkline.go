@@ -0,0 +1,82 @@
+package chart
+
+import (
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"math"
+)
+
+// Render draws the grid as a PNG image. Sparklines with less than two points are left out.
+func (grid SparklineGrid) Render(w io.Writer) error {
+	sparklines := make([]Sparkline, 0, len(grid.Sparklines))
+	for _, sparkline := range grid.Sparklines {
+		if len(sparkline.Points) >= 2 {
+			sparklines = append(sparklines, sparkline)
+		}
+	}
+	if len(sparklines) == 0 {
+		return ErrNotEnoughPoints
+	}
+
+	columns, cellWidth, cellHeight := grid.Columns, grid.CellWidth, grid.CellHeight
+	if columns <= 0 {
+		columns = defaultColumns
+	}
+	columns = min(columns, len(sparklines))
+	if cellWidth == 0 || cellHeight == 0 {
+		cellWidth, cellHeight = defaultCellWidth, defaultCellHeight
+	}
+
+	titleHeight := 0
+	if grid.Title != "" {
+		titleHeight = 24
+	}
+	rows := (len(sparklines) + columns - 1) / columns
+	img := newCanvas(columns*cellWidth, titleHeight+rows*cellHeight)
+	if grid.Title != "" {
+		drawText(img, cellPadding, 17, grid.Title, Foreground)
+	}
+
+	for i, sparkline := range sparklines {
+		x, y := (i%columns)*cellWidth, titleHeight+(i/columns)*cellHeight
+		drawSparkline(img, image.Rect(x, y, x+cellWidth, y+cellHeight), sparkline)
+	}
+
+	return png.Encode(w, img)
+}
+
+// drawSparkline draws the label and the overall change on top of the cell, then the curve below,
+// green when the last value is above the first one and red otherwise.
+func drawSparkline(img *image.RGBA, cell image.Rectangle, sparkline Sparkline) {
+	first, last := sparkline.Points[0], sparkline.Points[len(sparkline.Points)-1]
+	c := Green
+	if last.Value < first.Value {
+		c = Red
+	}
+
+	drawText(img, cell.Min.X+cellPadding, cell.Min.Y+cellPadding+10, sparkline.Label, Foreground)
+	if first.Value != 0 {
+		change := fmt.Sprintf("%+.1f%%", (last.Value-first.Value)/first.Value*100)
+		drawText(img, cell.Max.X-cellPadding-textWidth(change), cell.Min.Y+cellPadding+10, change, c)
+	}
+
+	minValue, maxValue := math.Inf(1), math.Inf(-1)
+	for _, point := range sparkline.Points {
+		minValue = math.Min(minValue, point.Value)
+		maxValue = math.Max(maxValue, point.Value)
+	}
+	minValue, maxValue = pad(minValue, maxValue)
+	if !last.Time.After(first.Time) {
+		return
+	}
+
+	area := image.Rect(cell.Min.X+cellPadding, cell.Min.Y+cellPadding+18, cell.Max.X-cellPadding, cell.Max.Y-cellPadding)
+	scale := newScale(area, first.Time, last.Time, minValue, maxValue, false)
+	drawLine(img, cell.Min.X+cellPadding, cell.Max.Y-1, cell.Max.X-cellPadding, cell.Max.Y-1, 1, Grid)
+	for i := 1; i < len(sparkline.Points); i++ {
+		previous, current := sparkline.Points[i-1], sparkline.Points[i]
+		drawLine(img, scale.x(previous.Time), scale.y(previous.Value), scale.x(current.Time), scale.y(current.Value), 1, c)
+	}
+}
@@ -0,0 +1,102 @@
+package chart
+
+import (
+	"errors"
+	"image/color"
+	"time"
+)
+
+const (
+	defaultWidth  = 800
+	defaultHeight = 400
+	marginLeft    = 70
+	marginRight   = 20
+	marginTop     = 36
+	marginBottom  = 30
+	yTicks        = 5
+	lineThickness = 2
+	// Part of the slot of a candle filled by its body.
+	candleBodyRatio   = 0.6
+	defaultColumns    = 3
+	defaultCellWidth  = 260
+	defaultCellHeight = 90
+	cellPadding       = 8
+)
+
+var (
+	ErrNotEnoughPoints = errors.New("at least two points are needed to draw a chart")
+
+	Background = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	Foreground = color.RGBA{R: 0x33, G: 0x33, B: 0x33, A: 0xff}
+	Grid       = color.RGBA{R: 0xe0, G: 0xe0, B: 0xe0, A: 0xff}
+	Blue       = color.RGBA{R: 0x1f, G: 0x77, B: 0xb4, A: 0xff}
+	Orange     = color.RGBA{R: 0xff, G: 0x7f, B: 0x0e, A: 0xff}
+	Green      = color.RGBA{R: 0x2c, G: 0xa0, B: 0x2c, A: 0xff}
+	Red        = color.RGBA{R: 0xd6, G: 0x27, B: 0x28, A: 0xff}
+	Purple     = color.RGBA{R: 0x94, G: 0x67, B: 0xbd, A: 0xff}
+)
+
+type Point struct {
+	Time  time.Time
+	Value float64
+}
+
+type Series struct {
+	Name   string
+	Color  color.Color
+	Points []Point
+}
+
+// Band highlights a horizontal range of values, e.g. the fear and greed zones.
+type Band struct {
+	From  float64
+	To    float64
+	Color color.Color
+}
+
+type LineChart struct {
+	Title  string
+	Width  int
+	Height int
+	Series []Series
+	Bands  []Band
+	// Fixed bounds of the value axis; computed from the points when both are zero.
+	YMin float64
+	YMax float64
+	// Draws the lowest values on top, e.g. for a ranking.
+	InvertY bool
+	// Formats the value axis labels, 2 decimals by default.
+	FormatValue func(value float64) string
+}
+
+// Candle summarises the values of a period.
+type Candle struct {
+	Time  time.Time
+	Open  float64
+	High  float64
+	Low   float64
+	Close float64
+}
+
+type CandlestickChart struct {
+	Title   string
+	Width   int
+	Height  int
+	Candles []Candle
+	// Formats the value axis labels, 2 decimals by default.
+	FormatValue func(value float64) string
+}
+
+type Sparkline struct {
+	Label  string
+	Points []Point
+}
+
+// SparklineGrid lays out small axis-less charts, one per cell.
+type SparklineGrid struct {
+	Title      string
+	Columns    int
+	CellWidth  int
+	CellHeight int
+	Sparklines []Sparkline
+}
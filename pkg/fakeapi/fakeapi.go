@@ -0,0 +1,143 @@
+package fakeapi
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+)
+
+// New builds a fake API serving the given fixtures; the embedded ones are used when nil.
+// Fixtures are looked up by name, with a more specific variant taking precedence:
+// listings_historical_<date>.json, detail_lite_<id>.json and profile_<handle>.json.
+func New(fixtures fs.FS) *Server {
+	if fixtures == nil {
+		fixtures, _ = fs.Sub(defaultFixtures, "fixtures")
+	}
+
+	server := &Server{fixtures: fixtures, mux: http.NewServeMux()}
+	server.mux.HandleFunc("GET /data-api/v3/cryptocurrency/listings/historical", server.historical)
+	server.mux.HandleFunc("GET /data-api/v3/cryptocurrency/listing", server.listing)
+	server.mux.HandleFunc("GET /data-api/v3/cryptocurrency/detail/lite", server.detailLite)
+	server.mux.HandleFunc("POST /gravity/v3/gravity/profile/query", server.profile)
+	server.mux.HandleFunc("GET /v0/widgets/fear-and-greed-index", server.serveFixture(fearAndGreedFixture))
+	server.mux.HandleFunc("GET /v0/global", server.serveFixture(globalIndicatorFixture))
+
+	return server
+}
+
+// NewHTTPTestServer starts a fake API on a random local port; its URL can be used
+// as both CMC_BASE_URL and CRYPTORANK_BASE_URL.
+func NewHTTPTestServer(fixtures fs.FS) *httptest.Server {
+	return httptest.NewServer(New(fixtures))
+}
+
+func (server *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	server.mux.ServeHTTP(w, r)
+}
+
+func (server *Server) historical(w http.ResponseWriter, r *http.Request) {
+	var payload map[string]json.RawMessage
+	if !server.readFixture(w, &payload, historicalFixture+"_"+r.URL.Query().Get("date"), historicalFixture) {
+		return
+	}
+
+	var data []json.RawMessage
+	if err := json.Unmarshal(payload["data"], &data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, map[string]any{"data": paginate(data, r)})
+}
+
+func (server *Server) listing(w http.ResponseWriter, r *http.Request) {
+	var payload struct {
+		Data map[string]json.RawMessage `json:"data"`
+	}
+	if !server.readFixture(w, &payload, listingFixture) {
+		return
+	}
+
+	var data []json.RawMessage
+	if err := json.Unmarshal(payload.Data["cryptoCurrencyList"], &data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	payload.Data["cryptoCurrencyList"], _ = json.Marshal(paginate(data, r))
+	writeJSON(w, payload)
+}
+
+func (server *Server) detailLite(w http.ResponseWriter, r *http.Request) {
+	server.serveFixture(detailLiteFixture+"_"+r.URL.Query().Get("id"))(w, r)
+}
+
+func (server *Server) profile(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Handle string `json:"handle"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	server.serveFixture(profileFixture+"_"+body.Handle)(w, r)
+}
+
+func (server *Server) serveFixture(names ...string) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		var payload json.RawMessage
+		if server.readFixture(w, &payload, names...) {
+			writeJSON(w, payload)
+		}
+	}
+}
+
+// readFixture decodes the first existing fixture among names, or answers 404.
+func (server *Server) readFixture(w http.ResponseWriter, target any, names ...string) bool {
+	for _, name := range names {
+		content, err := fs.ReadFile(server.fixtures, name+fixtureExtension)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err == nil {
+			err = json.Unmarshal(content, target)
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return false
+		}
+		return true
+	}
+
+	http.NotFound(w, nil)
+	return false
+}
+
+// paginate applies the 1-based start and limit query parameters used by CMC listings.
+func paginate(data []json.RawMessage, r *http.Request) []json.RawMessage {
+	start, errStart := strconv.Atoi(r.URL.Query().Get("start"))
+	if errStart != nil || start < 1 {
+		start = 1
+	}
+	limit, errLimit := strconv.Atoi(r.URL.Query().Get("limit"))
+	if errLimit != nil || limit < 1 {
+		limit = len(data)
+	}
+
+	if start > len(data) {
+		return []json.RawMessage{}
+	}
+	end := min(start-1+limit, len(data))
+	return data[start-1 : end]
+}
+
+func writeJSON(w http.ResponseWriter, payload any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(payload); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
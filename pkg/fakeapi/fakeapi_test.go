@@ -0,0 +1,113 @@
+package fakeapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestRouting(t *testing.T) {
+	server := New(nil)
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   string
+		status int
+		want   string
+	}{
+		{"historical listing", http.MethodGet, "/data-api/v3/cryptocurrency/listings/historical?date=2025-03-01&start=1&limit=200", "", http.StatusOK, `"slug":"iexec-rlc"`},
+		{"trending listing", http.MethodGet, "/data-api/v3/cryptocurrency/listing?start=1&limit=50&sortBy=trending_24h", "", http.StatusOK, `"cryptoCurrencyList"`},
+		{"watch count by id", http.MethodGet, "/data-api/v3/cryptocurrency/detail/lite?id=1637", "", http.StatusOK, `"watchCount":"98234"`},
+		{"unknown id", http.MethodGet, "/data-api/v3/cryptocurrency/detail/lite?id=42", "", http.StatusNotFound, ""},
+		{"profile by handle", http.MethodPost, "/gravity/v3/gravity/profile/query", `{"handle":"IExecRLC"}`, http.StatusOK, `"followers":"15234"`},
+		{"unknown handle", http.MethodPost, "/gravity/v3/gravity/profile/query", `{"handle":"nobody"}`, http.StatusNotFound, ""},
+		{"invalid profile query", http.MethodPost, "/gravity/v3/gravity/profile/query", `{`, http.StatusBadRequest, ""},
+		{"fear and greed", http.MethodGet, "/v0/widgets/fear-and-greed-index", "", http.StatusOK, `"today":32`},
+		{"global indicator", http.MethodGet, "/v0/global", "", http.StatusOK, `"btcDominance":61.2`},
+		{"wrong method", http.MethodPost, "/v0/global", "", http.StatusMethodNotAllowed, ""},
+		{"unknown route", http.MethodGet, "/v1/unknown", "", http.StatusNotFound, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			server.ServeHTTP(recorder, httptest.NewRequest(test.method, test.target, strings.NewReader(test.body)))
+
+			if recorder.Code != test.status {
+				t.Fatalf("status = %d, want %d: %s", recorder.Code, test.status, recorder.Body)
+			}
+			if test.want != "" && !strings.Contains(recorder.Body.String(), test.want) {
+				t.Errorf("body does not contain %s: %s", test.want, recorder.Body)
+			}
+		})
+	}
+}
+
+func TestHistoricalPagination(t *testing.T) {
+	server := New(nil)
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"start=1&limit=2", []string{"BTC", "ETH"}},
+		{"start=6&limit=200", []string{"PHA", "AKT"}},
+		{"start=8&limit=200", []string{}},
+		{"", []string{"BTC", "ETH", "GLM", "RLC", "SCRT", "PHA", "AKT"}},
+	}
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			var response struct {
+				Data []struct {
+					Symbol string `json:"symbol"`
+				} `json:"data"`
+			}
+			decode(t, server, "/data-api/v3/cryptocurrency/listings/historical?"+test.query, &response)
+
+			symbols := make([]string, 0, len(response.Data))
+			for _, crypto := range response.Data {
+				symbols = append(symbols, crypto.Symbol)
+			}
+			if strings.Join(symbols, ",") != strings.Join(test.want, ",") {
+				t.Errorf("symbols = %v, want %v", symbols, test.want)
+			}
+		})
+	}
+}
+
+func TestDatedFixtureTakesPrecedence(t *testing.T) {
+	server := New(fstest.MapFS{
+		"listings_historical.json":            {Data: []byte(`{"data": [{"symbol": "ANY"}]}`)},
+		"listings_historical_2025-03-01.json": {Data: []byte(`{"data": [{"symbol": "DATED"}]}`)},
+	})
+
+	for date, want := range map[string]string{"2025-03-01": "DATED", "2025-03-02": "ANY"} {
+		var response struct {
+			Data []struct {
+				Symbol string `json:"symbol"`
+			} `json:"data"`
+		}
+		decode(t, server, "/data-api/v3/cryptocurrency/listings/historical?date="+date, &response)
+
+		if len(response.Data) != 1 || response.Data[0].Symbol != want {
+			t.Errorf("date %s: data = %+v, want %s", date, response.Data, want)
+		}
+	}
+}
+
+func decode(t *testing.T, server *Server, target string, response any) {
+	t.Helper()
+
+	recorder := httptest.NewRecorder()
+	server.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, target, nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", recorder.Code, recorder.Body)
+	}
+	if err := json.NewDecoder(recorder.Body).Decode(response); err != nil {
+		t.Fatalf("cannot decode response: %v", err)
+	}
+}
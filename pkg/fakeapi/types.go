@@ -0,0 +1,27 @@
+package fakeapi
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+)
+
+const (
+	historicalFixture      = "listings_historical"
+	listingFixture         = "listing"
+	detailLiteFixture      = "detail_lite"
+	profileFixture         = "profile"
+	fearAndGreedFixture    = "fear_and_greed_index"
+	globalIndicatorFixture = "global"
+	fixtureExtension       = ".json"
+)
+
+//go:embed fixtures/*.json
+var defaultFixtures embed.FS
+
+// Server fakes the CoinMarketCap and CryptoRank endpoints used by the services,
+// answering with recorded JSON fixtures.
+type Server struct {
+	fixtures fs.FS
+	mux      *http.ServeMux
+}
@@ -0,0 +1,156 @@
+package indicators
+
+import "math"
+
+// SMA is the mean of the last period values.
+func SMA(values []float64, period int) (float64, error) {
+	if err := check(values, period); err != nil {
+		return 0, err
+	}
+	return mean(values[len(values)-period:]), nil
+}
+
+// EMA is the exponential moving average of the values, seeded with the SMA of the first period values.
+func EMA(values []float64, period int) (float64, error) {
+	if err := check(values, period); err != nil {
+		return 0, err
+	}
+	series := emaSeries(values, period)
+	return series[len(series)-1], nil
+}
+
+// RSI is the relative strength index, smoothed the Wilder way.
+func RSI(values []float64, period int) (float64, error) {
+	if err := check(values, period+1); err != nil {
+		return 0, err
+	}
+
+	var gain, loss float64
+	for i := 1; i <= period; i++ {
+		delta := values[i] - values[i-1]
+		gain += math.Max(delta, 0)
+		loss += math.Max(-delta, 0)
+	}
+	gain, loss = gain/float64(period), loss/float64(period)
+
+	for i := period + 1; i < len(values); i++ {
+		delta := values[i] - values[i-1]
+		gain = (gain*float64(period-1) + math.Max(delta, 0)) / float64(period)
+		loss = (loss*float64(period-1) + math.Max(-delta, 0)) / float64(period)
+	}
+
+	if loss == 0 {
+		if gain == 0 {
+			return 50, nil
+		}
+		return 100, nil
+	}
+	return 100 - 100/(1+gain/loss), nil
+}
+
+// MACD is the gap between a fast and a slow EMA, with the EMA of that gap as signal line.
+func MACD(values []float64, fast int, slow int, signal int) (MACDValue, error) {
+	if fast <= 0 || signal <= 0 || slow <= fast {
+		return MACDValue{}, ErrInvalidPeriod
+	}
+	if err := check(values, slow+signal-1); err != nil {
+		return MACDValue{}, err
+	}
+
+	fastSeries, slowSeries := emaSeries(values, fast), emaSeries(values, slow)
+	// Both series end on the last value; the fast one starts earlier.
+	offset := slow - fast
+	macdSeries := make([]float64, len(slowSeries))
+	for i := range slowSeries {
+		macdSeries[i] = fastSeries[i+offset] - slowSeries[i]
+	}
+
+	signalSeries := emaSeries(macdSeries, signal)
+	result := MACDValue{MACD: macdSeries[len(macdSeries)-1], Signal: signalSeries[len(signalSeries)-1]}
+	result.Histogram = result.MACD - result.Signal
+	return result, nil
+}
+
+// BollingerBands is the SMA of the last period values surrounded by k standard deviations.
+func BollingerBands(values []float64, period int, k float64) (Bands, error) {
+	if err := check(values, period); err != nil {
+		return Bands{}, err
+	}
+
+	window := values[len(values)-period:]
+	middle := mean(window)
+	deviation := stdDev(window, middle)
+	return Bands{Lower: middle - k*deviation, Middle: middle, Upper: middle + k*deviation}, nil
+}
+
+// RealizedVolatility is the annualized standard deviation of the daily log returns over the last period days.
+func RealizedVolatility(values []float64, period int) (float64, error) {
+	if err := check(values, period+1); err != nil {
+		return 0, err
+	}
+
+	window := values[len(values)-period-1:]
+	returns := make([]float64, 0, period)
+	for i := 1; i < len(window); i++ {
+		if window[i-1] <= 0 || window[i] <= 0 {
+			return 0, ErrNotEnoughValues
+		}
+		returns = append(returns, math.Log(window[i]/window[i-1]))
+	}
+
+	return stdDev(returns, mean(returns)) * math.Sqrt(365), nil
+}
+
+// MaxDrawdown is the largest drop from a peak to a following trough, as a negative ratio.
+func MaxDrawdown(values []float64) (float64, error) {
+	if len(values) < 2 {
+		return 0, ErrNotEnoughValues
+	}
+
+	peak, drawdown := values[0], 0.0
+	for _, value := range values[1:] {
+		peak = math.Max(peak, value)
+		if peak > 0 {
+			drawdown = math.Min(drawdown, (value-peak)/peak)
+		}
+	}
+	return drawdown, nil
+}
+
+func check(values []float64, size int) error {
+	if size <= 0 {
+		return ErrInvalidPeriod
+	}
+	if len(values) < size {
+		return ErrNotEnoughValues
+	}
+	return nil
+}
+
+// emaSeries returns the EMA for each value from the period-th one.
+func emaSeries(values []float64, period int) []float64 {
+	alpha := 2 / float64(period+1)
+	series := make([]float64, 0, len(values)-period+1)
+	series = append(series, mean(values[:period]))
+	for _, value := range values[period:] {
+		previous := series[len(series)-1]
+		series = append(series, previous+alpha*(value-previous))
+	}
+	return series
+}
+
+func mean(values []float64) float64 {
+	sum := 0.0
+	for _, value := range values {
+		sum += value
+	}
+	return sum / float64(len(values))
+}
+
+func stdDev(values []float64, mean float64) float64 {
+	sum := 0.0
+	for _, value := range values {
+		sum += (value - mean) * (value - mean)
+	}
+	return math.Sqrt(sum / float64(len(values)))
+}
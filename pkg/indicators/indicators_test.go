@@ -0,0 +1,227 @@
+package indicators
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// Closes of the StockCharts moving average example.
+var emaCloses = []float64{22.27, 22.19, 22.08, 22.17, 22.18, 22.13, 22.23, 22.43, 22.24, 22.29, 22.15, 22.39, 22.38, 22.61, 23.36,
+	24.05, 23.75, 23.83, 23.95, 23.63, 23.82, 23.87, 23.65, 23.19, 23.10, 23.33, 22.68, 23.10, 22.40, 22.17}
+
+// Closes of the StockCharts RSI example, whose reference values are computed without rounding the average gains and losses.
+var rsiCloses = []float64{44.34, 44.09, 44.15, 43.61, 44.33, 44.83, 45.10, 45.42, 45.84, 46.08, 45.89, 46.03, 45.61, 46.28, 46.28,
+	46.00, 46.03, 46.41, 46.22, 45.64, 46.21, 46.25, 45.71, 46.45, 45.78, 45.35, 44.03, 44.18, 44.22, 44.57, 43.42, 42.66, 43.13}
+
+func flat(value float64, size int) []float64 {
+	values := make([]float64, size)
+	for i := range values {
+		values[i] = value
+	}
+	return values
+}
+
+func linear(size int) []float64 {
+	values := make([]float64, size)
+	for i := range values {
+		values[i] = float64(i)
+	}
+	return values
+}
+
+func assertClose(t *testing.T, name string, got float64, want float64, tolerance float64) {
+	t.Helper()
+	if math.Abs(got-want) > tolerance {
+		t.Errorf("%s = %v, want %v", name, got, want)
+	}
+}
+
+func TestSMA(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		period int
+		want   float64
+		err    error
+	}{
+		{"last period values", []float64{1, 2, 3, 4, 5}, 3, 4, nil},
+		{"whole series", []float64{1, 2, 3, 4, 5}, 5, 3, nil},
+		{"flat", flat(7, 20), 10, 7, nil},
+		{"short series", []float64{1, 2}, 3, 0, ErrNotEnoughValues},
+		{"no period", []float64{1, 2}, 0, 0, ErrInvalidPeriod},
+	}
+	for _, test := range tests {
+		got, err := SMA(test.values, test.period)
+		if !errors.Is(err, test.err) {
+			t.Errorf("%s: error = %v, want %v", test.name, err, test.err)
+		}
+		assertClose(t, test.name, got, test.want, 1e-9)
+	}
+}
+
+func TestEMA(t *testing.T) {
+	tests := []struct {
+		size int
+		want float64
+	}{
+		{10, 22.22},
+		{11, 22.21},
+		{16, 22.80},
+		{20, 23.34},
+		{30, 22.92},
+	}
+	for _, test := range tests {
+		got, err := EMA(emaCloses[:test.size], 10)
+		if err != nil {
+			t.Fatalf("EMA of %d values: %v", test.size, err)
+		}
+		assertClose(t, "EMA", got, test.want, 0.005)
+	}
+
+	if got, err := EMA(flat(3, 15), 10); err != nil || got != 3 {
+		t.Errorf("EMA of a flat series = (%v, %v), want 3", got, err)
+	}
+	if _, err := EMA(emaCloses[:9], 10); !errors.Is(err, ErrNotEnoughValues) {
+		t.Errorf("EMA of a short series: error = %v, want %v", err, ErrNotEnoughValues)
+	}
+}
+
+func TestRSI(t *testing.T) {
+	tests := []struct {
+		size int
+		want float64
+	}{
+		{15, 70.46},
+		{16, 66.25},
+		{17, 66.48},
+		{20, 57.92},
+		{27, 40.02},
+		{33, 37.79},
+	}
+	for _, test := range tests {
+		got, err := RSI(rsiCloses[:test.size], 14)
+		if err != nil {
+			t.Fatalf("RSI of %d values: %v", test.size, err)
+		}
+		assertClose(t, "RSI", got, test.want, 0.005)
+	}
+
+	edgeCases := []struct {
+		name   string
+		values []float64
+		want   float64
+		err    error
+	}{
+		{"flat", flat(1, 20), 50, nil},
+		{"only gains", linear(20), 100, nil},
+		{"only losses", []float64{5, 4, 3, 2, 1}, 0, nil},
+		{"short series", linear(14), 0, ErrNotEnoughValues},
+	}
+	for _, test := range edgeCases {
+		period := 14
+		if test.name == "only losses" {
+			period = 4
+		}
+		got, err := RSI(test.values, period)
+		if !errors.Is(err, test.err) {
+			t.Errorf("%s: error = %v, want %v", test.name, err, test.err)
+		}
+		assertClose(t, test.name, got, test.want, 1e-9)
+	}
+}
+
+func TestMACD(t *testing.T) {
+	// On a linear series each EMA lags by (period-1)/2, the MACD is constant and equal to its signal.
+	got, err := MACD(linear(60), 12, 26, 9)
+	if err != nil {
+		t.Fatalf("MACD of a linear series: %v", err)
+	}
+	assertClose(t, "MACD", got.MACD, 7, 1e-9)
+	assertClose(t, "signal", got.Signal, 7, 1e-9)
+	assertClose(t, "histogram", got.Histogram, 0, 1e-9)
+
+	got, err = MACD(flat(2, 34), 12, 26, 9)
+	if err != nil || got != (MACDValue{}) {
+		t.Errorf("MACD of a flat series = (%+v, %v), want zero", got, err)
+	}
+
+	errorCases := []struct {
+		name   string
+		values []float64
+		fast   int
+		slow   int
+		err    error
+	}{
+		{"short series", linear(33), 12, 26, ErrNotEnoughValues},
+		{"slow not slower", linear(60), 26, 12, ErrInvalidPeriod},
+		{"no fast period", linear(60), 0, 26, ErrInvalidPeriod},
+	}
+	for _, test := range errorCases {
+		if _, err := MACD(test.values, test.fast, test.slow, 9); !errors.Is(err, test.err) {
+			t.Errorf("%s: error = %v, want %v", test.name, err, test.err)
+		}
+	}
+}
+
+func TestBollingerBands(t *testing.T) {
+	// A population standard deviation of 2 around 5.
+	got, err := BollingerBands([]float64{100, 2, 4, 4, 4, 5, 5, 7, 9}, 8, 2)
+	if err != nil {
+		t.Fatalf("bands: %v", err)
+	}
+	if got != (Bands{Lower: 1, Middle: 5, Upper: 9}) {
+		t.Errorf("bands = %+v, want {1 5 9}", got)
+	}
+
+	got, err = BollingerBands(flat(3, 20), 20, 2)
+	if err != nil || got != (Bands{Lower: 3, Middle: 3, Upper: 3}) {
+		t.Errorf("bands of a flat series = (%+v, %v), want {3 3 3}", got, err)
+	}
+	if _, err := BollingerBands(flat(3, 19), 20, 2); !errors.Is(err, ErrNotEnoughValues) {
+		t.Errorf("bands of a short series: error = %v, want %v", err, ErrNotEnoughValues)
+	}
+}
+
+func TestRealizedVolatility(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		want   float64
+		err    error
+	}{
+		{"alternating", []float64{1, 100, 110, 100, 110, 100}, math.Log(1.1) * math.Sqrt(365), nil},
+		{"flat", flat(4, 10), 0, nil},
+		{"short series", flat(4, 4), 0, ErrNotEnoughValues},
+		{"null price", []float64{100, 0, 110, 100, 110}, 0, ErrNotEnoughValues},
+	}
+	for _, test := range tests {
+		got, err := RealizedVolatility(test.values, 4)
+		if !errors.Is(err, test.err) {
+			t.Errorf("%s: error = %v, want %v", test.name, err, test.err)
+		}
+		assertClose(t, test.name, got, test.want, 1e-9)
+	}
+}
+
+func TestMaxDrawdown(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		want   float64
+		err    error
+	}{
+		{"deepest drop after the highest peak", []float64{100, 120, 90, 110, 60, 130, 117}, -0.5, nil},
+		{"drop before a new peak", []float64{100, 50, 200, 180}, -0.5, nil},
+		{"only rising", []float64{1, 2, 3}, 0, nil},
+		{"flat", flat(5, 10), 0, nil},
+		{"short series", []float64{1}, 0, ErrNotEnoughValues},
+	}
+	for _, test := range tests {
+		got, err := MaxDrawdown(test.values)
+		if !errors.Is(err, test.err) {
+			t.Errorf("%s: error = %v, want %v", test.name, err, test.err)
+		}
+		assertClose(t, test.name, got, test.want, 1e-9)
+	}
+}
@@ -0,0 +1,20 @@
+package indicators
+
+import "errors"
+
+var (
+	ErrNotEnoughValues = errors.New("not enough values for this indicator")
+	ErrInvalidPeriod   = errors.New("indicator period must be positive")
+)
+
+type MACDValue struct {
+	MACD      float64
+	Signal    float64
+	Histogram float64
+}
+
+type Bands struct {
+	Lower  float64
+	Middle float64
+	Upper  float64
+}
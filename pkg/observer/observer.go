@@ -15,10 +15,14 @@ const (
 type Event struct {
 	E    EventType
 	Feed *gofeed.Item
+	// Name of the feed the item comes from.
+	Source string
+	// Symbols of the tokens mentioned by the item.
+	Symbols []string
 }
 
-func NewRSSEvent(item *gofeed.Item) Event {
-	return Event{Feed: item, E: RSSEvent}
+func NewRSSEvent(source string, item *gofeed.Item, symbols []string) Event {
+	return Event{Feed: item, Source: source, Symbols: symbols, E: RSSEvent}
 }
 
 type Observer interface {
@@ -0,0 +1,55 @@
+package alerts
+
+import (
+	"crypto-analytics/models/entities"
+	"crypto-analytics/utils/databases"
+)
+
+func New(db databases.SqlConnection) *Impl {
+	return &Impl{db: db}
+}
+
+func (repo *Impl) Create(alert *entities.PriceAlert) error {
+	return repo.db.GetDB().Create(alert).Error
+}
+
+func (repo *Impl) Save(alert entities.PriceAlert) error {
+	return repo.db.GetDB().Save(&alert).Error
+}
+
+func (repo *Impl) Delete(chatID int64, id uint) (int64, error) {
+	result := repo.db.GetDB().Where("chat_id = ?", chatID).Delete(&entities.PriceAlert{}, id)
+	return result.RowsAffected, result.Error
+}
+
+func (repo *Impl) DeleteAllForUser(chatID int64) error {
+	return repo.db.GetDB().Where("chat_id = ?", chatID).Delete(&entities.PriceAlert{}).Error
+}
+
+func (repo *Impl) FetchAll() ([]entities.PriceAlert, error) {
+	var alerts []entities.PriceAlert
+	result := repo.db.GetDB().Find(&alerts)
+
+	return alerts, result.Error
+}
+
+func (repo *Impl) FetchForUser(chatID int64) ([]entities.PriceAlert, error) {
+	var alerts []entities.PriceAlert
+	result := repo.db.GetDB().Where("chat_id = ?", chatID).Order("id").Find(&alerts)
+
+	return alerts, result.Error
+}
+
+func (repo *Impl) FetchSymbols() ([]string, error) {
+	var symbols []string
+	result := repo.db.GetDB().Model(&entities.PriceAlert{}).Distinct("symbol").Order("symbol").Pluck("symbol", &symbols)
+
+	return symbols, result.Error
+}
+
+func (repo *Impl) CountForUser(chatID int64) int64 {
+	count := new(int64)
+	repo.db.GetDB().Model(&entities.PriceAlert{}).Where("chat_id = ?", chatID).Count(count)
+
+	return *count
+}
@@ -0,0 +1,21 @@
+package alerts
+
+import (
+	"crypto-analytics/models/entities"
+	"crypto-analytics/utils/databases"
+)
+
+type Repository interface {
+	Create(alert *entities.PriceAlert) error
+	Save(alert entities.PriceAlert) error
+	Delete(chatID int64, id uint) (int64, error)
+	DeleteAllForUser(chatID int64) error
+	FetchAll() ([]entities.PriceAlert, error)
+	FetchForUser(chatID int64) ([]entities.PriceAlert, error)
+	FetchSymbols() ([]string, error)
+	CountForUser(chatID int64) int64
+}
+
+type Impl struct {
+	db databases.SqlConnection
+}
@@ -0,0 +1,48 @@
+package backfill
+
+import (
+	"crypto-analytics/models/entities"
+	"crypto-analytics/utils/databases"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func New(db databases.SqlConnection) *Impl {
+	return &Impl{db: db}
+}
+
+func (repo *Impl) Get() (entities.BackfillProgress, error) {
+	var progress entities.BackfillProgress
+	result := repo.db.GetDB().Where("id = ?", progressID).First(&progress)
+
+	return progress, result.Error
+}
+
+func (repo *Impl) Save(progress entities.BackfillProgress) error {
+	progress.ID = progressID
+	return repo.db.GetDB().Save(&progress).Error
+}
+
+// Acquire saves the progress of a starting backfill, unless another one is running, i.e. updated after staleBefore.
+// The row is read and written in a single transaction, the processes sharing the database cannot both acquire it.
+func (repo *Impl) Acquire(progress entities.BackfillProgress, staleBefore time.Time) (bool, error) {
+	progress.ID = progressID
+	acquired := false
+	err := repo.db.GetDB().Transaction(func(tx *gorm.DB) error {
+		var current entities.BackfillProgress
+		errCurrent := tx.Where("id = ?", progressID).First(&current).Error
+		if errCurrent != nil && !errors.Is(errCurrent, gorm.ErrRecordNotFound) {
+			return errCurrent
+		}
+		if errCurrent == nil && current.Running && current.UpdatedAt.After(staleBefore) {
+			return nil
+		}
+
+		acquired = true
+		return tx.Save(&progress).Error
+	})
+
+	return acquired && err == nil, err
+}
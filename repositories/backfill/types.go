@@ -0,0 +1,20 @@
+package backfill
+
+import (
+	"crypto-analytics/models/entities"
+	"crypto-analytics/utils/databases"
+	"time"
+)
+
+// progressID is the single row holding the state of the historical backfill.
+const progressID = 1
+
+type Repository interface {
+	Get() (entities.BackfillProgress, error)
+	Save(progress entities.BackfillProgress) error
+	Acquire(progress entities.BackfillProgress, staleBefore time.Time) (bool, error)
+}
+
+type Impl struct {
+	db databases.SqlConnection
+}
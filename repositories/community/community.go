@@ -43,9 +43,24 @@ func (repo *Impl) Count() int64 {
 	return *count
 }
 
-func (repo *Impl) FetchForSymbolYesterday(id int, day string) (entities.CommunityData, error) {
+func (repo *Impl) FetchForDay(id int, day string) (entities.CommunityData, error) {
 	var existing entities.CommunityData
 	result := repo.db.GetDB().Where("cid = ?", id).Where("day = ?", day).First(&existing)
 
 	return existing, result.Error
 }
+
+func (repo *Impl) FetchBetween(id int, from string, to string) ([]entities.CommunityData, error) {
+	var communityData []entities.CommunityData
+	result := repo.db.GetDB().Where("cid = ?", id).Where("day BETWEEN ? AND ?", from, to).Order("day").Find(&communityData)
+
+	return communityData, result.Error
+}
+
+func (repo *Impl) FetchPageBetween(id int, from string, to string, offset int, limit int) ([]entities.CommunityData, int64, error) {
+	var communityData []entities.CommunityData
+	query := repo.db.GetDB().Model(&entities.CommunityData{}).Where("cid = ?", id).Where("day BETWEEN ? AND ?", from, to).Order("day")
+	total, err := databases.Paginate(query, offset, limit, &communityData)
+
+	return communityData, total, err
+}
@@ -7,7 +7,9 @@ import (
 
 type Repository interface {
 	Save(crypto entities.CommunityData) error
-	FetchForSymbolYesterday(id int, day string) (entities.CommunityData, error)
+	FetchForDay(id int, day string) (entities.CommunityData, error)
+	FetchBetween(id int, from string, to string) ([]entities.CommunityData, error)
+	FetchPageBetween(id int, from string, to string, offset int, limit int) ([]entities.CommunityData, int64, error)
 	Count() int64
 }
 
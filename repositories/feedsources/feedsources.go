@@ -3,6 +3,7 @@ package feedsources
 import (
 	"crypto-analytics/models/entities"
 	"crypto-analytics/utils/databases"
+	"time"
 )
 
 func New(db databases.SqlConnection) *Impl {
@@ -11,23 +12,44 @@ func New(db databases.SqlConnection) *Impl {
 
 func (repo *Impl) GetFeedSources() ([]entities.FeedSource, error) {
 	var feedSources []entities.FeedSource
-	response := repo.db.GetDB().Model(&entities.FeedSource{}).Find(&feedSources)
+	response := repo.db.GetDB().Model(&entities.FeedSource{}).Order("feed_type_id").Find(&feedSources)
 	return feedSources, response.Error
 }
 
+func (repo *Impl) FindByID(feedTypeID string) (entities.FeedSource, error) {
+	var feedSource entities.FeedSource
+	result := repo.db.GetDB().Where("feed_type_id = ?", feedTypeID).First(&feedSource)
+
+	return feedSource, result.Error
+}
+
 func (repo *Impl) Create(feedSource entities.FeedSource) error {
 	return repo.db.GetDB().Create(&feedSource).Error
 }
 
+// Save stores the state of the last read of a source, leaving its settings untouched.
 func (repo *Impl) Save(feedSource entities.FeedSource) error {
 	return repo.db.GetDB().
 		Model(&feedSource).
 		Where("feed_type_id = ? ",
 			feedSource.FeedTypeID).
-		Update("last_update", feedSource.LastUpdate).
+		Select("last_update", "format", "e_tag", "last_modified", "last_fetch").
+		Updates(feedSource).
 		Error
 }
 
+func (repo *Impl) Delete(feedTypeID string) error {
+	return repo.db.GetDB().Where("feed_type_id = ?", feedTypeID).Delete(&entities.FeedSource{}).Error
+}
+
+func (repo *Impl) SetPaused(feedTypeID string, paused bool) error {
+	return repo.db.GetDB().Model(&entities.FeedSource{}).Where("feed_type_id = ?", feedTypeID).Update("paused", paused).Error
+}
+
+func (repo *Impl) SetInterval(feedTypeID string, interval time.Duration) error {
+	return repo.db.GetDB().Model(&entities.FeedSource{}).Where("feed_type_id = ?", feedTypeID).Update("interval", interval).Error
+}
+
 func (repo *Impl) Count() int64 {
 	count := new(int64)
 	repo.db.GetDB().Model(&entities.FeedSource{}).Count(count)
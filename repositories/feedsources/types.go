@@ -3,12 +3,17 @@ package feedsources
 import (
 	"crypto-analytics/models/entities"
 	"crypto-analytics/utils/databases"
+	"time"
 )
 
 type Repository interface {
 	GetFeedSources() ([]entities.FeedSource, error)
+	FindByID(feedTypeID string) (entities.FeedSource, error)
 	Create(feedSource entities.FeedSource) error
 	Save(feedSource entities.FeedSource) error
+	Delete(feedTypeID string) error
+	SetPaused(feedTypeID string, paused bool) error
+	SetInterval(feedTypeID string, interval time.Duration) error
 	Count() int64
 }
 
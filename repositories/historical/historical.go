@@ -20,16 +20,88 @@ func (repo *Impl) Count() int64 {
 	return *count
 }
 
+// FetchForSymbolForDay returns the best ranked token with this symbol, several tokens may share it.
 func (repo *Impl) FetchForSymbolForDay(symbol string, day string) (entities.Historical, error) {
 	var existingHistorical entities.Historical
-	result := repo.db.GetDB().Where("symbol = ?", symbol).Where("day = ?", day).First(&existingHistorical)
+	result := repo.db.GetDB().Where("symbol = ?", symbol).Where("day = ?", day).Order("\"rank\" = 0, \"rank\"").Take(&existingHistorical)
 
 	return existingHistorical, result.Error
 }
 
+// FetchForIDForDay returns the row of a CMC id, the best ranked one when stored twice after a slug change.
+func (repo *Impl) FetchForIDForDay(id int, day string) (entities.Historical, error) {
+	var existingHistorical entities.Historical
+	result := repo.db.GetDB().Where("id = ?", id).Where("day = ?", day).Order("\"rank\" = 0, \"rank\"").Take(&existingHistorical)
+
+	return existingHistorical, result.Error
+}
+
+// FetchLatestForSymbolUntil returns the best ranked token with this symbol on the latest day stored up to the given one.
+func (repo *Impl) FetchLatestForSymbolUntil(symbol string, day string) (entities.Historical, error) {
+	var historical entities.Historical
+	result := repo.db.GetDB().Where("symbol = ?", symbol).Where("day <= ?", day).Order("day DESC, \"rank\" = 0, \"rank\"").Take(&historical)
+
+	return historical, result.Error
+}
+
 func (repo *Impl) FetchForDay(day string) ([]entities.Historical, error) {
 	var existingHistorical []entities.Historical
-	result := repo.db.GetDB().Where("day = ?", day).First(&existingHistorical)
+	result := repo.db.GetDB().Where("day = ?", day).Order("\"rank\"").Find(&existingHistorical)
 
 	return existingHistorical, result.Error
 }
+
+func (repo *Impl) FetchTopForDay(day string, maxRank int) ([]entities.Historical, error) {
+	var historicals []entities.Historical
+	result := repo.db.GetDB().Where("day = ?", day).Where("\"rank\" BETWEEN 1 AND ?", maxRank).Order("\"rank\"").Find(&historicals)
+
+	return historicals, result.Error
+}
+
+func (repo *Impl) FetchBetween(from string, to string) ([]entities.Historical, error) {
+	var historicals []entities.Historical
+	result := repo.db.GetDB().Where("day BETWEEN ? AND ?", from, to).Order("day, \"rank\"").Find(&historicals)
+
+	return historicals, result.Error
+}
+
+func (repo *Impl) FetchForSymbolBetween(symbol string, from string, to string) ([]entities.Historical, error) {
+	var historicals []entities.Historical
+	result := repo.db.GetDB().Where("symbol = ?", symbol).Where("day BETWEEN ? AND ?", from, to).Order("day").Find(&historicals)
+
+	return historicals, result.Error
+}
+
+func (repo *Impl) FetchPageForIDBetween(id int, from string, to string, offset int, limit int) ([]entities.Historical, int64, error) {
+	var historicals []entities.Historical
+	query := repo.db.GetDB().Model(&entities.Historical{}).Where("id = ?", id).Where("day BETWEEN ? AND ?", from, to).Order("day")
+	total, err := databases.Paginate(query, offset, limit, &historicals)
+
+	return historicals, total, err
+}
+
+func (repo *Impl) FetchForIDBetween(id int, from string, to string) ([]entities.Historical, error) {
+	var historicals []entities.Historical
+	result := repo.db.GetDB().Where("id = ?", id).Where("day BETWEEN ? AND ?", from, to).Order("day").Find(&historicals)
+
+	return historicals, result.Error
+}
+
+func (repo *Impl) FetchLatestDay() (string, error) {
+	var day string
+	result := repo.db.GetDB().Model(&entities.Historical{}).Select("MAX(day)").Scan(&day)
+
+	return day, result.Error
+}
+
+func (repo *Impl) FetchPagesBetween(from string, to string, pageSize int) ([]DayPage, error) {
+	var pages []DayPage
+	result := repo.db.GetDB().Model(&entities.Historical{}).
+		Select("day, (\"rank\" - 1) / ? AS page, COUNT(*) AS count", pageSize).
+		Where("day BETWEEN ? AND ?", from, to).
+		Where("\"rank\" > 0").
+		Group("day, page").
+		Scan(&pages)
+
+	return pages, result.Error
+}
@@ -9,7 +9,23 @@ type Repository interface {
 	Save(crypto entities.Historical) error
 	Count() int64
 	FetchForSymbolForDay(symbol string, day string) (entities.Historical, error)
+	FetchForIDForDay(id int, day string) (entities.Historical, error)
+	FetchLatestForSymbolUntil(symbol string, day string) (entities.Historical, error)
 	FetchForDay(day string) ([]entities.Historical, error)
+	FetchTopForDay(day string, maxRank int) ([]entities.Historical, error)
+	FetchBetween(from string, to string) ([]entities.Historical, error)
+	FetchForSymbolBetween(symbol string, from string, to string) ([]entities.Historical, error)
+	FetchForIDBetween(id int, from string, to string) ([]entities.Historical, error)
+	FetchPageForIDBetween(id int, from string, to string, offset int, limit int) ([]entities.Historical, int64, error)
+	FetchLatestDay() (string, error)
+	FetchPagesBetween(from string, to string, pageSize int) ([]DayPage, error)
+}
+
+// DayPage tells how many rows of a listing page are stored for a day.
+type DayPage struct {
+	Day   string
+	Page  int
+	Count int64
 }
 
 type Impl struct {
@@ -0,0 +1,42 @@
+package historicalfailures
+
+import (
+	"crypto-analytics/models/entities"
+	"crypto-analytics/utils/databases"
+)
+
+func New(db databases.SqlConnection) *Impl {
+	return &Impl{db: db}
+}
+
+func (repo *Impl) Save(failure entities.HistoricalFailure) error {
+	return repo.db.GetDB().Save(&failure).Error
+}
+
+func (repo *Impl) Delete(day string, start int) error {
+	return repo.db.GetDB().
+		Where("day = ?", day).
+		Where("start = ?", start).
+		Delete(&entities.HistoricalFailure{}).Error
+}
+
+func (repo *Impl) Find(day string, start int) (entities.HistoricalFailure, error) {
+	var failure entities.HistoricalFailure
+	result := repo.db.GetDB().Where("day = ?", day).Where("start = ?", start).First(&failure)
+
+	return failure, result.Error
+}
+
+func (repo *Impl) FetchAll() ([]entities.HistoricalFailure, error) {
+	var failures []entities.HistoricalFailure
+	result := repo.db.GetDB().Order("day").Order("start").Find(&failures)
+
+	return failures, result.Error
+}
+
+func (repo *Impl) Count() int64 {
+	count := new(int64)
+	repo.db.GetDB().Model(&entities.HistoricalFailure{}).Count(count)
+
+	return *count
+}
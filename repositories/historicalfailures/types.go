@@ -0,0 +1,18 @@
+package historicalfailures
+
+import (
+	"crypto-analytics/models/entities"
+	"crypto-analytics/utils/databases"
+)
+
+type Repository interface {
+	Save(failure entities.HistoricalFailure) error
+	Delete(day string, start int) error
+	Find(day string, start int) (entities.HistoricalFailure, error)
+	FetchAll() ([]entities.HistoricalFailure, error)
+	Count() int64
+}
+
+type Impl struct {
+	db databases.SqlConnection
+}
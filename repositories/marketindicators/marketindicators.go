@@ -0,0 +1,58 @@
+package marketindicators
+
+import (
+	"crypto-analytics/models/entities"
+	"crypto-analytics/utils/databases"
+	"time"
+)
+
+func New(db databases.SqlConnection) *Impl {
+	return &Impl{db: db}
+}
+
+// Timestamps are stored in UTC, bounds are converted so that they compare as stored.
+func (repo *Impl) Save(indicator entities.MarketIndicator) error {
+	indicator.Timestamp = indicator.Timestamp.UTC()
+	return repo.db.GetDB().Save(&indicator).Error
+}
+
+func (repo *Impl) FetchLatest() (entities.MarketIndicator, error) {
+	var indicator entities.MarketIndicator
+	result := repo.db.GetDB().Order("timestamp desc").First(&indicator)
+
+	return indicator, result.Error
+}
+
+func (repo *Impl) FetchLatestBefore(before time.Time) (entities.MarketIndicator, error) {
+	var indicator entities.MarketIndicator
+	result := repo.db.GetDB().Where("timestamp <= ?", before.UTC()).Order("timestamp desc").First(&indicator)
+
+	return indicator, result.Error
+}
+
+func (repo *Impl) FetchBetween(from time.Time, to time.Time) ([]entities.MarketIndicator, error) {
+	var indicators []entities.MarketIndicator
+	result := repo.db.GetDB().Where("timestamp BETWEEN ? AND ?", from.UTC(), to.UTC()).Order("timestamp").Find(&indicators)
+
+	return indicators, result.Error
+}
+
+func (repo *Impl) FetchPageBetween(from time.Time, to time.Time, offset int, limit int) ([]entities.MarketIndicator, int64, error) {
+	var indicators []entities.MarketIndicator
+	query := repo.db.GetDB().Model(&entities.MarketIndicator{}).Where("timestamp BETWEEN ? AND ?", from.UTC(), to.UTC()).Order("timestamp")
+	total, err := databases.Paginate(query, offset, limit, &indicators)
+
+	return indicators, total, err
+}
+
+// FetchDailyBetween keeps the last indicator of each day, both bounds included.
+func (repo *Impl) FetchDailyBetween(from string, to string) ([]entities.MarketIndicator, error) {
+	var indicators []entities.MarketIndicator
+	lastOfDay := repo.db.GetDB().Model(&entities.MarketIndicator{}).
+		Select("MAX(id)").
+		Where("day BETWEEN ? AND ?", from, to).
+		Group("day")
+	result := repo.db.GetDB().Where("id IN (?)", lastOfDay).Order("day").Find(&indicators)
+
+	return indicators, result.Error
+}
@@ -0,0 +1,20 @@
+package marketindicators
+
+import (
+	"crypto-analytics/models/entities"
+	"crypto-analytics/utils/databases"
+	"time"
+)
+
+type Repository interface {
+	Save(indicator entities.MarketIndicator) error
+	FetchLatest() (entities.MarketIndicator, error)
+	FetchLatestBefore(before time.Time) (entities.MarketIndicator, error)
+	FetchBetween(from time.Time, to time.Time) ([]entities.MarketIndicator, error)
+	FetchPageBetween(from time.Time, to time.Time, offset int, limit int) ([]entities.MarketIndicator, int64, error)
+	FetchDailyBetween(from string, to string) ([]entities.MarketIndicator, error)
+}
+
+type Impl struct {
+	db databases.SqlConnection
+}
@@ -0,0 +1,91 @@
+package news
+
+import (
+	"crypto-analytics/models/entities"
+	"crypto-analytics/utils/databases"
+	"strings"
+	"time"
+	"unicode"
+)
+
+func New(db databases.SqlConnection) *Impl {
+	return &Impl{db: db}
+}
+
+func (repo *Impl) Exists(feedTypeID string, guid string) (bool, error) {
+	count := new(int64)
+	result := repo.db.GetDB().Model(&entities.NewsItem{}).Where("feed_type_id = ?", feedTypeID).Where("guid = ?", guid).Count(count)
+
+	return *count > 0, result.Error
+}
+
+func (repo *Impl) Create(item entities.NewsItem) error {
+	return repo.db.GetDB().Create(&item).Error
+}
+
+func (repo *Impl) CountForSource(feedTypeID string) int64 {
+	count := new(int64)
+	repo.db.GetDB().Model(&entities.NewsItem{}).Where("feed_type_id = ?", feedTypeID).Count(count)
+
+	return *count
+}
+
+func (repo *Impl) Count() int64 {
+	count := new(int64)
+	repo.db.GetDB().Model(&entities.NewsItem{}).Count(count)
+
+	return *count
+}
+
+// FetchForSymbol returns the latest items mentioning a symbol.
+func (repo *Impl) FetchForSymbol(symbol string, limit int) ([]entities.NewsItem, error) {
+	var items []entities.NewsItem
+	result := repo.db.GetDB().
+		Where("symbols = ? OR symbols LIKE ? OR symbols LIKE ? OR symbols LIKE ?", symbol, symbol+";%", "%;"+symbol, "%;"+symbol+";%").
+		Order("published_at DESC").
+		Limit(limit).
+		Find(&items)
+
+	return items, result.Error
+}
+
+// Search returns the items published since a time containing every word of a query, or words starting
+// with it, the most relevant first; a match in the title weighs more than in the symbols, the summary then the categories.
+func (repo *Impl) Search(query string, since time.Time, limit int) ([]entities.NewsItem, error) {
+	match := matchQuery(query)
+	if match == "" {
+		return nil, nil
+	}
+
+	var items []entities.NewsItem
+	result := repo.db.GetDB().
+		Table("news_items").
+		Select("news_items.*").
+		Joins("JOIN news_items_fts ON news_items_fts.rowid = news_items.id").
+		Where("news_items_fts MATCH ?", match).
+		Where("news_items.published_at >= ?", since.UTC()).
+		Order("bm25(news_items_fts, 10.0, 2.0, 1.0, 5.0)").
+		Order("news_items.published_at DESC").
+		Limit(limit).
+		Find(&items)
+
+	return items, result.Error
+}
+
+func (repo *Impl) DeleteOlderThan(before time.Time) (int64, error) {
+	result := repo.db.GetDB().Where("published_at < ?", before.UTC()).Delete(&entities.NewsItem{})
+	return result.RowsAffected, result.Error
+}
+
+// matchQuery turns a user query into a FTS5 one, each word being quoted not to be read as an operator.
+func matchQuery(query string) string {
+	words := strings.FieldsFunc(query, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	terms := make([]string, 0, len(words))
+	for _, word := range words {
+		terms = append(terms, `"`+word+`"*`)
+	}
+	return strings.Join(terms, " ")
+}
@@ -0,0 +1,21 @@
+package news
+
+import (
+	"crypto-analytics/models/entities"
+	"crypto-analytics/utils/databases"
+	"time"
+)
+
+type Repository interface {
+	Exists(feedTypeID string, guid string) (bool, error)
+	Create(item entities.NewsItem) error
+	CountForSource(feedTypeID string) int64
+	Count() int64
+	FetchForSymbol(symbol string, limit int) ([]entities.NewsItem, error)
+	Search(query string, since time.Time, limit int) ([]entities.NewsItem, error)
+	DeleteOlderThan(before time.Time) (int64, error)
+}
+
+type Impl struct {
+	db databases.SqlConnection
+}
@@ -0,0 +1,38 @@
+package quotes
+
+import (
+	"crypto-analytics/models/entities"
+	"crypto-analytics/utils/databases"
+	"time"
+)
+
+func New(db databases.SqlConnection) *Impl {
+	return &Impl{db: db}
+}
+
+func (repo *Impl) Save(quote entities.Quote) error {
+	return repo.db.GetDB().Save(&quote).Error
+}
+
+func (repo *Impl) FetchLatestForSymbol(symbol string) (entities.Quote, error) {
+	var quote entities.Quote
+	result := repo.db.GetDB().Where("symbol = ?", symbol).Order("timestamp desc").First(&quote)
+
+	return quote, result.Error
+}
+
+func (repo *Impl) FetchLatestForSymbolBefore(symbol string, before time.Time) (entities.Quote, error) {
+	var quote entities.Quote
+	result := repo.db.GetDB().
+		Where("symbol = ?", symbol).
+		Where("timestamp <= ?", before).
+		Order("timestamp desc").
+		First(&quote)
+
+	return quote, result.Error
+}
+
+func (repo *Impl) DeleteOlderThan(before time.Time) (int64, error) {
+	result := repo.db.GetDB().Where("timestamp < ?", before).Delete(&entities.Quote{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,18 @@
+package quotes
+
+import (
+	"crypto-analytics/models/entities"
+	"crypto-analytics/utils/databases"
+	"time"
+)
+
+type Repository interface {
+	Save(quote entities.Quote) error
+	FetchLatestForSymbol(symbol string) (entities.Quote, error)
+	FetchLatestForSymbolBefore(symbol string, before time.Time) (entities.Quote, error)
+	DeleteOlderThan(before time.Time) (int64, error)
+}
+
+type Impl struct {
+	db databases.SqlConnection
+}
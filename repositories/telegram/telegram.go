@@ -48,3 +48,10 @@ func (repo *Impl) Delete(user entities.TelegramUser) error {
 	result := repo.db.GetDB().Delete(&entities.TelegramUser{}, user.ChatID)
 	return result.Error
 }
+
+func (repo *Impl) Count() int64 {
+	count := new(int64)
+	repo.db.GetDB().Model(&entities.TelegramUser{}).Count(count)
+
+	return *count
+}
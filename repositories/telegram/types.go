@@ -10,6 +10,7 @@ type Repository interface {
 	Delete(user entities.TelegramUser) error
 	FindByID(chatID int64) (entities.TelegramUser, error)
 	FetchAll() ([]entities.TelegramUser, error)
+	Count() int64
 }
 
 type Impl struct {
@@ -0,0 +1,49 @@
+package tokentags
+
+import (
+	"crypto-analytics/models/entities"
+	"crypto-analytics/utils/databases"
+
+	"gorm.io/gorm"
+)
+
+func New(db databases.SqlConnection) *Impl {
+	return &Impl{db: db}
+}
+
+// SaveForDay replaces, in one transaction, the tags of the tokens of a listing page, by slug.
+func (repo *Impl) SaveForDay(day string, tags map[string][]string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	slugs := make([]string, 0, len(tags))
+	var tokenTags []entities.TokenTag
+	for slug, slugTags := range tags {
+		slugs = append(slugs, slug)
+		for _, tag := range slugTags {
+			tokenTags = append(tokenTags, entities.TokenTag{Slug: slug, Day: day, Tag: tag})
+		}
+	}
+
+	return repo.db.GetDB().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("day = ?", day).Where("slug IN ?", slugs).Delete(&entities.TokenTag{}).Error; err != nil {
+			return err
+		}
+		if len(tokenTags) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(&tokenTags, createBatchSize).Error
+	})
+}
+
+// FetchForDay returns the tags of every token at a day, i.e. those of its latest listing until then.
+func (repo *Impl) FetchForDay(day string) ([]entities.TokenTag, error) {
+	var tokenTags []entities.TokenTag
+	result := repo.db.GetDB().
+		Joins("JOIN (SELECT slug, MAX(day) AS day FROM token_tags WHERE day <= ? GROUP BY slug) latest ON latest.slug = token_tags.slug AND latest.day = token_tags.day", day).
+		Order("token_tags.slug, token_tags.tag").
+		Find(&tokenTags)
+
+	return tokenTags, result.Error
+}
@@ -0,0 +1,18 @@
+package tokentags
+
+import (
+	"crypto-analytics/models/entities"
+	"crypto-analytics/utils/databases"
+)
+
+// Rows inserted per statement, SQLite limiting the number of bound parameters.
+const createBatchSize = 300
+
+type Repository interface {
+	SaveForDay(day string, tags map[string][]string) error
+	FetchForDay(day string) ([]entities.TokenTag, error)
+}
+
+type Impl struct {
+	db databases.SqlConnection
+}
@@ -26,3 +26,32 @@ func (repo *Impl) IsCryptoTrendyAtDay(symbol string, day string) (entities.Trend
 
 	return existingTrendingCrypto, result.Error
 }
+
+func (repo *Impl) FetchForDay(day string) ([]entities.TrendingCrypto, error) {
+	var trendingCryptos []entities.TrendingCrypto
+	result := repo.db.GetDB().Where("day = ?", day).Order("symbol").Find(&trendingCryptos)
+
+	return trendingCryptos, result.Error
+}
+
+func (repo *Impl) CountForSymbolBetween(symbol string, from string, to string) (int64, error) {
+	var count int64
+	result := repo.db.GetDB().Model(&entities.TrendingCrypto{}).Where("symbol = ?", symbol).Where("day BETWEEN ? AND ?", from, to).Distinct("day").Count(&count)
+
+	return count, result.Error
+}
+
+func (repo *Impl) FetchPageForDay(day string, offset int, limit int) ([]entities.TrendingCrypto, int64, error) {
+	var trendingCryptos []entities.TrendingCrypto
+	query := repo.db.GetDB().Model(&entities.TrendingCrypto{}).Where("day = ?", day).Order("symbol")
+	total, err := databases.Paginate(query, offset, limit, &trendingCryptos)
+
+	return trendingCryptos, total, err
+}
+
+func (repo *Impl) FetchLatest() (entities.TrendingCrypto, error) {
+	var trendingCrypto entities.TrendingCrypto
+	result := repo.db.GetDB().Order("updated_at DESC").Take(&trendingCrypto)
+
+	return trendingCrypto, result.Error
+}
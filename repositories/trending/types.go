@@ -9,6 +9,10 @@ type Repository interface {
 	Save(crypto entities.TrendingCrypto) error
 	Count() int64
 	IsCryptoTrendyAtDay(symbol string, day string) (entities.TrendingCrypto, error)
+	FetchForDay(day string) ([]entities.TrendingCrypto, error)
+	CountForSymbolBetween(symbol string, from string, to string) (int64, error)
+	FetchPageForDay(day string, offset int, limit int) ([]entities.TrendingCrypto, int64, error)
+	FetchLatest() (entities.TrendingCrypto, error)
 }
 
 type Impl struct {
@@ -39,13 +39,25 @@ func (repo *Impl) SaveOrUpdate(tweet entities.Tweet) error {
 func (repo *Impl) GetTweetBetweenTimestamps(startTimestamp int64, endTimestamp int64) ([]entities.Tweet, error) {
 	var tweets []entities.Tweet
 
-	res := repo.db.GetDB().Find(&tweets).
-		Where("timestamp > ?", startTimestamp).
-		Where("timestamp < ?", endTimestamp)
+	res := repo.db.GetDB().
+		Where("timestamp >= ?", startTimestamp).
+		Where("timestamp <= ?", endTimestamp).
+		Find(&tweets)
 
 	return tweets, res.Error
 }
 
+func (repo *Impl) FetchPageBetweenTimestamps(startTimestamp int64, endTimestamp int64, offset int, limit int) ([]entities.Tweet, int64, error) {
+	var tweets []entities.Tweet
+	query := repo.db.GetDB().Model(&entities.Tweet{}).
+		Where("timestamp >= ?", startTimestamp).
+		Where("timestamp <= ?", endTimestamp).
+		Order("timestamp DESC")
+	total, err := databases.Paginate(query, offset, limit, &tweets)
+
+	return tweets, total, err
+}
+
 func (repo *Impl) Count() int64 {
 	count := new(int64)
 	repo.db.GetDB().Model(&entities.Tweet{}).Count(count)
@@ -8,6 +8,7 @@ import (
 type Repository interface {
 	SaveOrUpdate(tweet entities.Tweet) error
 	GetTweetBetweenTimestamps(startTimestamp int64, endTimestamp int64) ([]entities.Tweet, error)
+	FetchPageBetweenTimestamps(startTimestamp int64, endTimestamp int64, offset int, limit int) ([]entities.Tweet, int64, error)
 	Count() int64
 }
 
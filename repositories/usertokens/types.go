@@ -0,0 +1,19 @@
+package usertokens
+
+import (
+	"crypto-analytics/models/entities"
+	"crypto-analytics/utils/databases"
+)
+
+type Repository interface {
+	Save(token entities.UserToken) error
+	Delete(token entities.UserToken) error
+	DeleteAllForUser(chatID int64) error
+	FetchForUser(chatID int64) ([]entities.UserToken, error)
+	FetchSymbols() ([]string, error)
+	CountForUser(chatID int64) int64
+}
+
+type Impl struct {
+	db databases.SqlConnection
+}
@@ -0,0 +1,46 @@
+package usertokens
+
+import (
+	"crypto-analytics/models/entities"
+	"crypto-analytics/utils/databases"
+)
+
+func New(db databases.SqlConnection) *Impl {
+	return &Impl{db: db}
+}
+
+func (repo *Impl) Save(token entities.UserToken) error {
+	return repo.db.GetDB().Save(&token).Error
+}
+
+func (repo *Impl) Delete(token entities.UserToken) error {
+	return repo.db.GetDB().
+		Where("chat_id = ?", token.ChatID).
+		Where("symbol = ?", token.Symbol).
+		Delete(&entities.UserToken{}).Error
+}
+
+func (repo *Impl) DeleteAllForUser(chatID int64) error {
+	return repo.db.GetDB().Where("chat_id = ?", chatID).Delete(&entities.UserToken{}).Error
+}
+
+func (repo *Impl) FetchForUser(chatID int64) ([]entities.UserToken, error) {
+	var tokens []entities.UserToken
+	result := repo.db.GetDB().Where("chat_id = ?", chatID).Order("symbol").Find(&tokens)
+
+	return tokens, result.Error
+}
+
+func (repo *Impl) FetchSymbols() ([]string, error) {
+	var symbols []string
+	result := repo.db.GetDB().Model(&entities.UserToken{}).Distinct("symbol").Order("symbol").Pluck("symbol", &symbols)
+
+	return symbols, result.Error
+}
+
+func (repo *Impl) CountForUser(chatID int64) int64 {
+	count := new(int64)
+	repo.db.GetDB().Model(&entities.UserToken{}).Where("chat_id = ?", chatID).Count(count)
+
+	return *count
+}
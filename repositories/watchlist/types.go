@@ -0,0 +1,18 @@
+package watchlist
+
+import (
+	"crypto-analytics/models/entities"
+	"crypto-analytics/utils/databases"
+)
+
+type Repository interface {
+	Save(token entities.WatchedToken) error
+	Delete(token entities.WatchedToken) error
+	FindBySymbol(symbol string) (entities.WatchedToken, error)
+	FetchAll() ([]entities.WatchedToken, error)
+	Count() int64
+}
+
+type Impl struct {
+	db databases.SqlConnection
+}
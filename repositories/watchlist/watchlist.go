@@ -0,0 +1,39 @@
+package watchlist
+
+import (
+	"crypto-analytics/models/entities"
+	"crypto-analytics/utils/databases"
+)
+
+func New(db databases.SqlConnection) *Impl {
+	return &Impl{db: db}
+}
+
+func (repo *Impl) Save(token entities.WatchedToken) error {
+	return repo.db.GetDB().Save(&token).Error
+}
+
+func (repo *Impl) Delete(token entities.WatchedToken) error {
+	return repo.db.GetDB().Delete(&entities.WatchedToken{}, token.CryptoID).Error
+}
+
+func (repo *Impl) FindBySymbol(symbol string) (entities.WatchedToken, error) {
+	var token entities.WatchedToken
+	result := repo.db.GetDB().Where("symbol = ?", symbol).First(&token)
+
+	return token, result.Error
+}
+
+func (repo *Impl) FetchAll() ([]entities.WatchedToken, error) {
+	var tokens []entities.WatchedToken
+	result := repo.db.GetDB().Order("crypto_id").Find(&tokens)
+
+	return tokens, result.Error
+}
+
+func (repo *Impl) Count() int64 {
+	count := new(int64)
+	repo.db.GetDB().Model(&entities.WatchedToken{}).Count(count)
+
+	return *count
+}
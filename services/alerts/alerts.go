@@ -0,0 +1,170 @@
+package alerts
+
+import (
+	"crypto-analytics/models/constants"
+	"crypto-analytics/models/entities"
+	alertsRepo "crypto-analytics/repositories/alerts"
+	cmcService "crypto-analytics/services/coinmarketcap"
+	"errors"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+)
+
+func New(repository alertsRepo.Repository, cmcService cmcService.Service) *Impl {
+	return &Impl{
+		repository: repository,
+		cmcService: cmcService,
+		cooldown:   viper.GetDuration(constants.AlertCooldown),
+	}
+}
+
+func (service *Impl) Add(chatID int64, symbol, kind string, threshold float64) (entities.PriceAlert, error) {
+	kind = strings.ToLower(kind)
+	symbol = strings.ToUpper(symbol)
+	if kind != KindAbove && kind != KindBelow && kind != KindMove {
+		return entities.PriceAlert{}, ErrInvalidKind
+	}
+	if threshold <= 0 {
+		return entities.PriceAlert{}, ErrInvalidThreshold
+	}
+	if service.repository.CountForUser(chatID) >= maxAlertsPerUser {
+		return entities.PriceAlert{}, ErrTooManyAlerts
+	}
+	price, previous, err := service.cmcService.FetchPriceChange24h(symbol)
+	if errors.Is(err, gorm.ErrRecordNotFound) || errors.Is(err, cmcService.ErrNoPriceReference) {
+		return entities.PriceAlert{}, ErrUnknownSymbol
+	}
+	if err != nil {
+		return entities.PriceAlert{}, err
+	}
+
+	alert := entities.PriceAlert{
+		ChatID:    chatID,
+		Symbol:    symbol,
+		Kind:      kind,
+		Threshold: threshold,
+	}
+	// Armed on the current side of the threshold, only a crossing fires the alert.
+	alert.Armed = !isConditionMet(alert, price, percentChange(price, previous))
+	if err := service.repository.Create(&alert); err != nil {
+		return entities.PriceAlert{}, err
+	}
+
+	return alert, nil
+}
+
+func (service *Impl) Delete(chatID int64, id uint) error {
+	deleted, err := service.repository.Delete(chatID, id)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return ErrAlertNotFound
+	}
+	return nil
+}
+
+func (service *Impl) DeleteAllForUser(chatID int64) error {
+	return service.repository.DeleteAllForUser(chatID)
+}
+
+func (service *Impl) ListForUser(chatID int64) ([]entities.PriceAlert, error) {
+	return service.repository.FetchForUser(chatID)
+}
+
+// Evaluate checks every alert against the latest prices. An alert fires once
+// when its condition becomes true, then is disarmed until the condition turns
+// false again; the cooldown prevents flapping around the threshold.
+func (service *Impl) Evaluate() []Trigger {
+	// Called on every price, ranking and market event, which may overlap.
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	alerts, err := service.repository.FetchAll()
+	if err != nil {
+		log.Error().Err(err).Msg("Cannot fetch price alerts")
+		return nil
+	}
+
+	var triggers []Trigger
+	quotes := make(map[string]quote)
+	now := time.Now().UTC()
+	for _, alert := range alerts {
+		q := service.fetchQuote(quotes, alert.Symbol)
+		if q.err != nil {
+			continue
+		}
+
+		price := q.price
+		change := percentChange(q.price, q.previous)
+
+		if !isConditionMet(alert, price, change) {
+			if !alert.Armed {
+				alert.Armed = true
+				service.save(alert)
+			}
+			continue
+		}
+
+		if !alert.Armed || now.Sub(alert.LastTriggered) < service.cooldown {
+			continue
+		}
+
+		alert.Armed = false
+		alert.LastTriggered = now
+		service.save(alert)
+		triggers = append(triggers, Trigger{Alert: alert, Price: price, PercentChange: change})
+	}
+
+	log.Info().Int("alerts", len(alerts)).Int("triggered", len(triggers)).Msg("Price alerts evaluated")
+	return triggers
+}
+
+func (service *Impl) save(alert entities.PriceAlert) {
+	if err := service.repository.Save(alert); err != nil {
+		log.Error().Err(err).Uint("alertID", alert.ID).Msg("Cannot save price alert")
+	}
+}
+
+func isConditionMet(alert entities.PriceAlert, price, change float64) bool {
+	switch alert.Kind {
+	case KindAbove:
+		return price >= alert.Threshold
+	case KindBelow:
+		return price <= alert.Threshold
+	case KindMove:
+		return math.Abs(change) >= alert.Threshold
+	default:
+		return false
+	}
+}
+
+func percentChange(price, previous float64) float64 {
+	if previous <= 0 {
+		return 0
+	}
+	return ((price - previous) / previous) * 100
+}
+
+type quote struct {
+	price    float64
+	previous float64
+	err      error
+}
+
+// fetchQuote loads the prices of a symbol once per evaluation.
+func (service *Impl) fetchQuote(quotes map[string]quote, symbol string) quote {
+	if q, found := quotes[symbol]; found {
+		return q
+	}
+
+	var q quote
+	q.price, q.previous, q.err = service.cmcService.FetchPriceChange24h(symbol)
+	quotes[symbol] = q
+	return q
+}
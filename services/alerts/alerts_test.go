@@ -0,0 +1,216 @@
+package alerts
+
+import (
+	"crypto-analytics/models/entities"
+	alertsRepo "crypto-analytics/repositories/alerts"
+	cmcService "crypto-analytics/services/coinmarketcap"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// fakeRepository keeps the alerts in memory, the other methods are left unimplemented.
+type fakeRepository struct {
+	alertsRepo.Repository
+	alerts map[uint]entities.PriceAlert
+	nextID uint
+}
+
+func newFakeRepository() *fakeRepository {
+	return &fakeRepository{alerts: make(map[uint]entities.PriceAlert)}
+}
+
+func (repo *fakeRepository) Create(alert *entities.PriceAlert) error {
+	repo.nextID++
+	alert.ID = repo.nextID
+	repo.alerts[alert.ID] = *alert
+	return nil
+}
+
+func (repo *fakeRepository) Save(alert entities.PriceAlert) error {
+	repo.alerts[alert.ID] = alert
+	return nil
+}
+
+func (repo *fakeRepository) FetchAll() ([]entities.PriceAlert, error) {
+	alerts := make([]entities.PriceAlert, 0, len(repo.alerts))
+	for _, alert := range repo.alerts {
+		alerts = append(alerts, alert)
+	}
+	sort.Slice(alerts, func(i, j int) bool { return alerts[i].ID < alerts[j].ID })
+	return alerts, nil
+}
+
+func (repo *fakeRepository) CountForUser(chatID int64) int64 {
+	var count int64
+	for _, alert := range repo.alerts {
+		if alert.ChatID == chatID {
+			count++
+		}
+	}
+	return count
+}
+
+// fakeCMC serves the price and the price 24 hours before of each symbol, or an error.
+type fakeCMC struct {
+	cmcService.Service
+	prices map[string][2]float64
+	err    error
+}
+
+func (cmc *fakeCMC) FetchPriceChange24h(symbol string) (float64, float64, error) {
+	if cmc.err != nil {
+		return 0, 0, cmc.err
+	}
+	prices, found := cmc.prices[symbol]
+	if !found {
+		return 0, 0, gorm.ErrRecordNotFound
+	}
+	return prices[0], prices[1], nil
+}
+
+func newTestService(prices map[string][2]float64) (*Impl, *fakeRepository, *fakeCMC) {
+	repository := newFakeRepository()
+	cmc := &fakeCMC{prices: prices}
+	return &Impl{repository: repository, cmcService: cmc, cooldown: time.Hour}, repository, cmc
+}
+
+func TestAddArmsOnTheCurrentSide(t *testing.T) {
+	tests := []struct {
+		name      string
+		kind      string
+		threshold float64
+		armed     bool
+	}{
+		{"above a higher threshold", KindAbove, 1.2, true},
+		{"above a reached threshold", KindAbove, 0.9, false},
+		{"below a lower threshold", KindBelow, 0.9, true},
+		{"below a reached threshold", KindBelow, 1.2, false},
+		{"move larger than the change", KindMove, 15, true},
+		{"move smaller than the change", KindMove, 5, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			service, _, _ := newTestService(map[string][2]float64{"RLC": {1.1, 1.0}})
+
+			alert, err := service.Add(1, "rlc", test.kind, test.threshold)
+			if err != nil {
+				t.Fatalf("cannot add alert: %v", err)
+			}
+			if alert.Symbol != "RLC" || alert.Armed != test.armed {
+				t.Errorf("alert = {Symbol: %s, Armed: %t}, want {RLC, %t}", alert.Symbol, alert.Armed, test.armed)
+			}
+		})
+	}
+}
+
+func TestAddErrors(t *testing.T) {
+	errDatabase := errors.New("database is locked")
+	tests := []struct {
+		name      string
+		symbol    string
+		kind      string
+		threshold float64
+		cmcErr    error
+		want      error
+	}{
+		{"invalid kind", "RLC", "sideways", 1, nil, ErrInvalidKind},
+		{"invalid threshold", "RLC", KindAbove, 0, nil, ErrInvalidThreshold},
+		{"unknown symbol", "NOPE", KindAbove, 1, nil, ErrUnknownSymbol},
+		{"no price reference", "RLC", KindMove, 5, cmcService.ErrNoPriceReference, ErrUnknownSymbol},
+		{"database error", "RLC", KindAbove, 1, errDatabase, errDatabase},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			service, repository, cmc := newTestService(map[string][2]float64{"RLC": {1.1, 1.0}})
+			cmc.err = test.cmcErr
+
+			_, err := service.Add(1, test.symbol, test.kind, test.threshold)
+			if !errors.Is(err, test.want) {
+				t.Errorf("error = %v, want %v", err, test.want)
+			}
+			if len(repository.alerts) != 0 {
+				t.Errorf("%d alerts saved, want none", len(repository.alerts))
+			}
+		})
+	}
+}
+
+func TestAddLimitsAlertsPerUser(t *testing.T) {
+	service, _, _ := newTestService(map[string][2]float64{"RLC": {1.1, 1.0}})
+	for i := 0; i < maxAlertsPerUser; i++ {
+		if _, err := service.Add(1, "RLC", KindAbove, 2); err != nil {
+			t.Fatalf("cannot add alert %d: %v", i, err)
+		}
+	}
+
+	if _, err := service.Add(1, "RLC", KindAbove, 2); !errors.Is(err, ErrTooManyAlerts) {
+		t.Errorf("error = %v, want %v", err, ErrTooManyAlerts)
+	}
+	if _, err := service.Add(2, "RLC", KindAbove, 2); err != nil {
+		t.Errorf("another user cannot add alert: %v", err)
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	service, repository, cmc := newTestService(map[string][2]float64{"RLC": {1.0, 1.0}})
+	alert, err := service.Add(1, "RLC", KindAbove, 1.2)
+	if err != nil {
+		t.Fatalf("cannot add alert: %v", err)
+	}
+	setPrice := func(price float64) { cmc.prices["RLC"] = [2]float64{price, 1.0} }
+	backdate := func(d time.Duration) {
+		stored := repository.alerts[alert.ID]
+		stored.LastTriggered = stored.LastTriggered.Add(-d)
+		repository.alerts[alert.ID] = stored
+	}
+
+	steps := []struct {
+		name      string
+		prepare   func()
+		triggered bool
+		armed     bool
+	}{
+		{"below the threshold", func() { setPrice(1.1) }, false, true},
+		{"crossing fires", func() { setPrice(1.3) }, true, false},
+		{"staying above does not fire again", func() { setPrice(1.4) }, false, false},
+		{"falling back re-arms", func() { setPrice(1.1) }, false, true},
+		{"crossing within the cooldown is ignored", func() { setPrice(1.3) }, false, true},
+		{"crossing after the cooldown fires", func() { backdate(2 * time.Hour) }, true, false},
+		{"prices unknown keep the state", func() { cmc.err = errors.New("no quote") }, false, false},
+	}
+	for _, step := range steps {
+		step.prepare()
+		triggers := service.Evaluate()
+
+		if triggered := len(triggers) == 1; triggered != step.triggered || len(triggers) > 1 {
+			t.Fatalf("%s: %d triggers, want triggered %t", step.name, len(triggers), step.triggered)
+		}
+		if step.triggered && (triggers[0].Alert.ID != alert.ID || triggers[0].Price != 1.3) {
+			t.Errorf("%s: trigger = %+v", step.name, triggers[0])
+		}
+		if armed := repository.alerts[alert.ID].Armed; armed != step.armed {
+			t.Errorf("%s: armed = %t, want %t", step.name, armed, step.armed)
+		}
+	}
+}
+
+func TestEvaluateMove(t *testing.T) {
+	service, _, cmc := newTestService(map[string][2]float64{"PHA": {1.0, 1.0}})
+	if _, err := service.Add(1, "PHA", KindMove, 5); err != nil {
+		t.Fatalf("cannot add alert: %v", err)
+	}
+
+	cmc.prices["PHA"] = [2]float64{0.9, 1.0}
+	triggers := service.Evaluate()
+
+	if len(triggers) != 1 {
+		t.Fatalf("%d triggers, want 1", len(triggers))
+	}
+	if change := triggers[0].PercentChange; change > -9.99 || change < -10.01 {
+		t.Errorf("percent change = %v, want -10", change)
+	}
+}
@@ -0,0 +1,47 @@
+package alerts
+
+import (
+	"crypto-analytics/models/entities"
+	alertsRepo "crypto-analytics/repositories/alerts"
+	cmcService "crypto-analytics/services/coinmarketcap"
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	KindAbove = "above"
+	KindBelow = "below"
+	KindMove  = "move"
+
+	maxAlertsPerUser = 10
+)
+
+var (
+	ErrInvalidKind      = errors.New("alert kind must be above, below or move")
+	ErrInvalidThreshold = errors.New("alert threshold must be positive")
+	ErrTooManyAlerts    = errors.New("too many alerts for this user")
+	ErrUnknownSymbol    = errors.New("no price known for this symbol")
+	ErrAlertNotFound    = errors.New("alert not found")
+)
+
+type Trigger struct {
+	Alert         entities.PriceAlert
+	Price         float64
+	PercentChange float64
+}
+
+type Service interface {
+	Add(chatID int64, symbol, kind string, threshold float64) (entities.PriceAlert, error)
+	Delete(chatID int64, id uint) error
+	DeleteAllForUser(chatID int64) error
+	ListForUser(chatID int64) ([]entities.PriceAlert, error)
+	Evaluate() []Trigger
+}
+
+type Impl struct {
+	repository alertsRepo.Repository
+	cmcService cmcService.Service
+	cooldown   time.Duration
+	mutex      sync.Mutex
+}
@@ -0,0 +1,94 @@
+package analytics
+
+import (
+	"crypto-analytics/pkg/indicators"
+	cmcService "crypto-analytics/services/coinmarketcap"
+	"crypto-analytics/services/sectors"
+	"crypto-analytics/services/watchlist"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/patrickmn/go-cache"
+)
+
+func New(cmcService cmcService.Service, sectorsService sectors.Service, watchlistService watchlist.Service) *Impl {
+	return &Impl{
+		cmcService:       cmcService,
+		sectorsService:   sectorsService,
+		watchlistService: watchlistService,
+		cache:            cache.New(1*time.Hour, 2*time.Hour),
+	}
+}
+
+// Compute evaluates the indicators of a symbol on the closes stored up to the given day.
+func (service *Impl) Compute(symbol string, day string) (TechnicalIndicators, error) {
+	symbol = strings.ToUpper(symbol)
+	historicals, err := service.cmcService.FetchForSymbolBetween(symbol, cmcService.HalvingDate, day)
+	if err != nil {
+		return TechnicalIndicators{}, err
+	}
+	if len(historicals) == 0 {
+		return TechnicalIndicators{}, ErrNoHistory
+	}
+
+	closes := make([]float64, 0, len(historicals))
+	for _, historical := range historicals {
+		closes = append(closes, historical.Price)
+	}
+
+	last := historicals[len(historicals)-1]
+	result := TechnicalIndicators{Symbol: symbol, Day: last.Day, Price: last.Price}
+	result.SMA20, _ = indicators.SMA(closes, 20)
+	result.SMA50, _ = indicators.SMA(closes, 50)
+	result.SMA200, _ = indicators.SMA(closes, 200)
+	result.EMA12, _ = indicators.EMA(closes, 12)
+	result.EMA26, _ = indicators.EMA(closes, 26)
+	result.RSI, _ = indicators.RSI(closes, rsiPeriod)
+	result.MACD, _ = indicators.MACD(closes, macdFast, macdSlow, macdSignal)
+	result.Bollinger, _ = indicators.BollingerBands(closes, bollingerPeriod, bollingerDeviations)
+	result.Volatility, _ = indicators.RealizedVolatility(closes, volatilityPeriod)
+	result.MaxDrawdown, _ = indicators.MaxDrawdown(closes)
+	result.Signals = signals(result, closes)
+
+	return result, nil
+}
+
+// signals lists the notable readings, comparing with the day before for crossovers.
+func signals(current TechnicalIndicators, closes []float64) []string {
+	var result []string
+	symbol := current.Symbol
+
+	if current.RSI > 0 && current.RSI <= rsiOversold {
+		result = append(result, fmt.Sprintf("%s RSI %.0f — oversold", symbol, current.RSI))
+	} else if current.RSI >= rsiOverbought {
+		result = append(result, fmt.Sprintf("%s RSI %.0f — overbought", symbol, current.RSI))
+	}
+
+	if current.Bollinger.Upper > 0 && current.Price > current.Bollinger.Upper {
+		result = append(result, fmt.Sprintf("%s above its upper Bollinger band — stretched", symbol))
+	} else if current.Bollinger.Lower > 0 && current.Price < current.Bollinger.Lower {
+		result = append(result, fmt.Sprintf("%s below its lower Bollinger band — stretched", symbol))
+	}
+
+	previous := closes[:len(closes)-1]
+	if previousMACD, err := indicators.MACD(previous, macdFast, macdSlow, macdSignal); err == nil && current.MACD != (indicators.MACDValue{}) {
+		if previousMACD.Histogram <= 0 && current.MACD.Histogram > 0 {
+			result = append(result, fmt.Sprintf("%s MACD crossed above its signal — bullish", symbol))
+		} else if previousMACD.Histogram >= 0 && current.MACD.Histogram < 0 {
+			result = append(result, fmt.Sprintf("%s MACD crossed below its signal — bearish", symbol))
+		}
+	}
+
+	previousSMA50, err50 := indicators.SMA(previous, 50)
+	previousSMA200, err200 := indicators.SMA(previous, 200)
+	if err50 == nil && err200 == nil {
+		if previousSMA50 <= previousSMA200 && current.SMA50 > current.SMA200 {
+			result = append(result, fmt.Sprintf("%s golden cross — SMA50 above SMA200", symbol))
+		} else if previousSMA50 >= previousSMA200 && current.SMA50 < current.SMA200 {
+			result = append(result, fmt.Sprintf("%s death cross — SMA50 below SMA200", symbol))
+		}
+	}
+
+	return result
+}
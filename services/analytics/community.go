@@ -0,0 +1,128 @@
+package analytics
+
+import (
+	"crypto-analytics/utils/dates"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// ComputeCommunityGrowth analyses the CMC followers and watch count of a symbol stored up to a day.
+func (service *Impl) ComputeCommunityGrowth(symbol string, day string) (CommunityGrowth, error) {
+	symbol = strings.ToUpper(symbol)
+	current, err := time.ParseInLocation(dates.DateFormat, day, time.Local)
+	if err != nil {
+		return CommunityGrowth{}, err
+	}
+
+	cryptoID := 0
+	if token, found := service.watchlistService.FindBySymbol(symbol); found {
+		cryptoID = token.CryptoID
+	} else if historical, errHisto := service.cmcService.FetchForSymbolForDay(symbol, day); errHisto == nil {
+		cryptoID = historical.ID
+	}
+	if cryptoID == 0 {
+		return CommunityGrowth{}, ErrNoCommunity
+	}
+
+	// One more day than the baseline to know the growth of its first day.
+	since := current.AddDate(0, 0, -communityBaselineDays-1).Format(dates.DateFormat)
+	rows, err := service.cmcService.FetchCommunityDataBetween(cryptoID, since, day)
+	if err != nil {
+		return CommunityGrowth{}, err
+	}
+
+	followers := make(map[string]int64, len(rows))
+	watchCounts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		followers[row.Day] = row.Followers
+		watchCounts[row.Day] = row.WatchCount
+	}
+	if followers[day] <= 0 && watchCounts[day] <= 0 {
+		return CommunityGrowth{}, ErrNoCommunity
+	}
+
+	result := CommunityGrowth{
+		Symbol:     symbol,
+		Day:        day,
+		Followers:  counterGrowth(followers, current),
+		WatchCount: counterGrowth(watchCounts, current),
+	}
+	for _, counter := range []struct {
+		label  string
+		growth CounterGrowth
+	}{{"watchlist", result.WatchCount}, {"followers", result.Followers}} {
+		if !counter.growth.Anomaly {
+			continue
+		}
+		signal := fmt.Sprintf("%s %s %+.1f%% today", symbol, counter.label, counter.growth.Daily)
+		if counter.growth.NormalRatio > 0 {
+			signal += fmt.Sprintf(", %.0fx normal", counter.growth.NormalRatio)
+		} else {
+			signal += ", usually flat"
+		}
+		result.Signals = append(result.Signals, signal)
+	}
+
+	return result, nil
+}
+
+// counterGrowth analyses the values of a counter by day; missing and zero values are unknown.
+func counterGrowth(values map[string]int64, day time.Time) CounterGrowth {
+	at := func(daysAgo int) int64 {
+		return values[day.AddDate(0, 0, -daysAgo).Format(dates.DateFormat)]
+	}
+
+	result := CounterGrowth{Value: at(0)}
+	if result.Value <= 0 {
+		return result
+	}
+	result.Daily, result.HasDaily = growth(at(1), result.Value)
+	result.Weekly, result.HasWeekly = growth(at(7), result.Value)
+	result.Monthly, result.HasMonthly = growth(at(communityBaselineDays), result.Value)
+	if !result.HasDaily {
+		return result
+	}
+
+	// Daily growths of the previous days, the most recent first.
+	var history []float64
+	for daysAgo := 1; daysAgo <= communityBaselineDays; daysAgo++ {
+		if daily, known := growth(at(daysAgo+1), at(daysAgo)); known {
+			history = append(history, daily)
+		}
+	}
+
+	week := history[:min(7, len(history))]
+	if len(week) > 0 {
+		sum := 0.0
+		for _, daily := range week {
+			sum += daily
+		}
+		result.Acceleration = result.Daily - sum/float64(len(week))
+		result.HasAcceleration = true
+	}
+
+	if len(history) < communityMinSamples {
+		return result
+	}
+	normal := 0.0
+	for _, daily := range history {
+		normal += math.Abs(daily)
+	}
+	normal /= float64(len(history))
+	if normal > 0 {
+		result.NormalRatio = math.Abs(result.Daily) / normal
+	}
+	result.Anomaly = math.Abs(result.Daily) >= anomalyMinGrowth && (normal == 0 || result.NormalRatio >= anomalyRatio)
+
+	return result
+}
+
+// growth compares two counters in percent; zero counters are considered unknown.
+func growth(before int64, after int64) (float64, bool) {
+	if before <= 0 || after <= 0 {
+		return 0, false
+	}
+	return float64(after-before) / float64(before) * 100, true
+}
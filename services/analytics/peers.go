@@ -0,0 +1,124 @@
+package analytics
+
+import (
+	"crypto-analytics/utils/dates"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ComparePeers compares a token with its configured competitors over the days before a day.
+// Without configured competitors, the tokens of its sectors closest in market cap are used.
+func (service *Impl) ComparePeers(symbol string, day string, days int) (PeerComparison, error) {
+	symbol = strings.ToUpper(symbol)
+	current, err := time.ParseInLocation(dates.DateFormat, day, time.Local)
+	if err != nil {
+		return PeerComparison{}, err
+	}
+	since := current.AddDate(0, 0, -days).Format(dates.DateFormat)
+
+	subject, found := service.peerStats(symbol, since, day)
+	if !found {
+		return PeerComparison{}, ErrNoHistory
+	}
+
+	peers := service.watchlistService.GetPeers(symbol)
+	if len(peers) == 0 {
+		peers = service.sectorPeers(symbol, day)
+	}
+	if len(peers) == 0 {
+		return PeerComparison{}, ErrNoPeers
+	}
+
+	comparison := PeerComparison{Day: day, Since: since, Tokens: []PeerStats{subject}}
+	for _, peer := range peers {
+		stats, peerFound := service.peerStats(peer, since, day)
+		if !peerFound {
+			continue
+		}
+		if subject.Marketcap > 0 {
+			stats.MarketcapRatio = stats.Marketcap / subject.Marketcap
+		}
+		comparison.Tokens = append(comparison.Tokens, stats)
+	}
+	comparison.Tokens[0].MarketcapRatio = 1
+
+	sort.SliceStable(comparison.Tokens[1:], func(i, j int) bool {
+		return comparison.Tokens[i+1].Marketcap > comparison.Tokens[j+1].Marketcap
+	})
+	return comparison, nil
+}
+
+// peerStats reads the stored listing, community data and trending days of a symbol between two days.
+func (service *Impl) peerStats(symbol string, since string, day string) (PeerStats, bool) {
+	historicals, err := service.cmcService.FetchForSymbolBetween(symbol, since, day)
+	if err != nil || len(historicals) == 0 || historicals[len(historicals)-1].Day != day {
+		return PeerStats{}, false
+	}
+
+	first, last := historicals[0], historicals[len(historicals)-1]
+	stats := PeerStats{
+		Symbol:       last.Symbol,
+		Name:         last.Name,
+		Rank:         last.Rank,
+		PreviousRank: first.Rank,
+		Price:        last.Price,
+		Marketcap:    last.Marketcap,
+		TrendingDays: service.cmcService.CountTrendingDays(symbol, since, day),
+	}
+	if first.Day == since && first.Price > 0 {
+		stats.Return = (last.Price - first.Price) / first.Price * 100
+		stats.HasReturn = true
+	}
+
+	community, errCommunity := service.cmcService.FetchCommunityDataForDay(last.ID, day)
+	previousCommunity, errPrevious := service.cmcService.FetchCommunityDataForDay(last.ID, since)
+	if errCommunity == nil && errPrevious == nil {
+		stats.WatchCountGrowth, stats.HasWatchCountGrowth = growth(previousCommunity.WatchCount, community.WatchCount)
+		stats.FollowersGrowth, stats.HasFollowersGrowth = growth(previousCommunity.Followers, community.Followers)
+	}
+
+	return stats, true
+}
+
+// sectorPeers picks the tokens sharing a sector with the symbol closest to it in market cap.
+func (service *Impl) sectorPeers(symbol string, day string) []string {
+	performances, err := service.ComputeSectorPerformance(day, 1)
+	if err != nil {
+		return nil
+	}
+
+	marketcap := 0.0
+	candidates := make(map[string]float64)
+	for _, performance := range performances {
+		isMember := false
+		for _, member := range performance.Members {
+			if member.Symbol == symbol {
+				isMember = true
+				marketcap = member.Marketcap
+			}
+		}
+		if !isMember {
+			continue
+		}
+		for _, member := range performance.Members {
+			if member.Symbol != symbol && member.Marketcap > 0 {
+				candidates[member.Symbol] = member.Marketcap
+			}
+		}
+	}
+
+	if marketcap <= 0 {
+		return nil
+	}
+
+	peers := make([]string, 0, len(candidates))
+	for peer := range candidates {
+		peers = append(peers, peer)
+	}
+	sort.Slice(peers, func(i, j int) bool {
+		return math.Abs(math.Log(candidates[peers[i]]/marketcap)) < math.Abs(math.Log(candidates[peers[j]]/marketcap))
+	})
+	return peers[:min(sectorPeersCount, len(peers))]
+}
@@ -0,0 +1,109 @@
+package analytics
+
+import (
+	"crypto-analytics/utils/dates"
+	"sort"
+	"time"
+)
+
+// Delta is positive when the token climbed.
+func (change RankChange) Delta() int {
+	return change.PreviousRank - change.Rank
+}
+
+// ComputeRankMovement compares the ranking of a day with the one days before,
+// keeping the limit biggest climbers and fallers; limit 0 keeps them all.
+func (service *Impl) ComputeRankMovement(day string, days int, limit int) (RankMovement, error) {
+	current, err := time.ParseInLocation(dates.DateFormat, day, time.Local)
+	if err != nil {
+		return RankMovement{}, err
+	}
+	since := current.AddDate(0, 0, -days).Format(dates.DateFormat)
+
+	ranking, err := service.cmcService.FetchTopForDay(day, rankUniverse)
+	if err != nil {
+		return RankMovement{}, err
+	}
+	previousRanking, err := service.cmcService.FetchTopForDay(since, rankUniverse)
+	if err != nil {
+		return RankMovement{}, err
+	}
+	if len(ranking) == 0 || len(previousRanking) == 0 {
+		return RankMovement{}, ErrNoRanking
+	}
+
+	changes := make(map[string]*RankChange, len(ranking))
+	for _, historical := range previousRanking {
+		changes[historical.Slug] = &RankChange{Slug: historical.Slug, Symbol: historical.Symbol, Name: historical.Name, PreviousRank: historical.Rank}
+	}
+	for _, historical := range ranking {
+		change, found := changes[historical.Slug]
+		if !found {
+			change = &RankChange{Slug: historical.Slug}
+			changes[historical.Slug] = change
+		}
+		change.Symbol, change.Name, change.Rank = historical.Symbol, historical.Name, historical.Rank
+	}
+
+	movement := RankMovement{Day: day, Since: since}
+	for _, change := range changes {
+		movement.Crossings = append(movement.Crossings, crossings(*change)...)
+		if change.Rank == 0 || change.PreviousRank == 0 {
+			continue
+		}
+		if change.Delta() > 0 {
+			movement.Climbers = append(movement.Climbers, *change)
+		} else if change.Delta() < 0 {
+			movement.Fallers = append(movement.Fallers, *change)
+		}
+	}
+
+	sort.Slice(movement.Climbers, func(i, j int) bool {
+		return movement.Climbers[i].Delta() > movement.Climbers[j].Delta() ||
+			movement.Climbers[i].Delta() == movement.Climbers[j].Delta() && movement.Climbers[i].Rank < movement.Climbers[j].Rank
+	})
+	sort.Slice(movement.Fallers, func(i, j int) bool {
+		return movement.Fallers[i].Delta() < movement.Fallers[j].Delta() ||
+			movement.Fallers[i].Delta() == movement.Fallers[j].Delta() && movement.Fallers[i].Rank < movement.Fallers[j].Rank
+	})
+	sort.Slice(movement.Crossings, func(i, j int) bool {
+		if movement.Crossings[i].Threshold != movement.Crossings[j].Threshold {
+			return movement.Crossings[i].Threshold < movement.Crossings[j].Threshold
+		}
+		return bestRank(movement.Crossings[i].RankChange) < bestRank(movement.Crossings[j].RankChange)
+	})
+
+	if limit > 0 {
+		movement.Climbers = movement.Climbers[:min(limit, len(movement.Climbers))]
+		movement.Fallers = movement.Fallers[:min(limit, len(movement.Fallers))]
+	}
+
+	return movement, nil
+}
+
+// crossings lists the thresholds entered or left by a token.
+func crossings(change RankChange) []ThresholdCrossing {
+	var result []ThresholdCrossing
+	for _, threshold := range RankThresholds {
+		inside := isInTop(change.Rank, threshold)
+		wasInside := isInTop(change.PreviousRank, threshold)
+		if inside != wasInside {
+			result = append(result, ThresholdCrossing{RankChange: change, Threshold: threshold, Entered: inside})
+		}
+	}
+	return result
+}
+
+func isInTop(rank int, threshold int) bool {
+	return rank > 0 && rank <= threshold
+}
+
+func bestRank(change RankChange) int {
+	if change.Rank == 0 {
+		return change.PreviousRank
+	}
+	if change.PreviousRank == 0 {
+		return change.Rank
+	}
+	return min(change.Rank, change.PreviousRank)
+}
@@ -0,0 +1,129 @@
+package analytics
+
+import (
+	"crypto-analytics/models/entities"
+	"crypto-analytics/utils/dates"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/patrickmn/go-cache"
+)
+
+// ComputeSectorPerformance aggregates the tokens of each configured sector listed on a day,
+// comparing them with the listing days before.
+func (service *Impl) ComputeSectorPerformance(day string, days int) ([]SectorPerformance, error) {
+	cacheKey := fmt.Sprintf("sectors_%s_%d", day, days)
+	if cached, found := service.cache.Get(cacheKey); found {
+		return cached.([]SectorPerformance), nil
+	}
+
+	current, err := time.ParseInLocation(dates.DateFormat, day, time.Local)
+	if err != nil {
+		return nil, err
+	}
+	since := current.AddDate(0, 0, -days).Format(dates.DateFormat)
+
+	listing, err := service.cmcService.FetchTopForDay(day, rankUniverse)
+	if err != nil {
+		return nil, err
+	}
+	if len(listing) == 0 {
+		return nil, ErrNoRanking
+	}
+	previousListing, err := service.cmcService.FetchTopForDay(since, rankUniverse)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := service.cmcService.FetchTokenTags(day)
+	if err != nil {
+		return nil, err
+	}
+	trending, err := service.cmcService.FetchTrendingForDay(day)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := make(map[string]entities.Historical, len(previousListing))
+	for _, historical := range previousListing {
+		previous[historical.Slug] = historical
+	}
+	trendingSlugs := make(map[string]bool, len(trending))
+	for _, crypto := range trending {
+		trendingSlugs[crypto.Slug] = true
+	}
+
+	performances := make(map[string]*SectorPerformance)
+	for _, sector := range service.sectorsService.GetSectors() {
+		performances[sector.Name] = &SectorPerformance{Name: sector.Name, Day: day, Since: since}
+	}
+
+	previousMarketcaps := make(map[string]float64)
+	comparableMarketcaps := make(map[string]float64)
+	for _, historical := range listing {
+		member := SectorMember{
+			Slug:      historical.Slug,
+			Symbol:    historical.Symbol,
+			Name:      historical.Name,
+			Rank:      historical.Rank,
+			Marketcap: historical.Marketcap,
+			Trending:  trendingSlugs[historical.Slug],
+		}
+		before, found := previous[historical.Slug]
+		if found && before.Price > 0 {
+			member.Return = (historical.Price - before.Price) / before.Price * 100
+			member.HasReturn = true
+		}
+
+		for _, name := range service.sectorsService.Match(tags[historical.Slug]) {
+			performance := performances[name]
+			performance.Members = append(performance.Members, member)
+			performance.TotalMarketcap += member.Marketcap
+			if member.Trending {
+				performance.Trending++
+			}
+			if found && before.Marketcap > 0 {
+				previousMarketcaps[name] += before.Marketcap
+				comparableMarketcaps[name] += member.Marketcap
+			}
+		}
+	}
+
+	result := make([]SectorPerformance, 0, len(performances))
+	for _, sector := range service.sectorsService.GetSectors() {
+		performance := performances[sector.Name]
+		if len(performance.Members) == 0 {
+			continue
+		}
+		if previousMarketcaps[sector.Name] > 0 {
+			performance.MarketcapChangePercent = (comparableMarketcaps[sector.Name] - previousMarketcaps[sector.Name]) / previousMarketcaps[sector.Name] * 100
+		}
+		performance.MedianReturn = medianReturn(performance.Members)
+		sort.SliceStable(performance.Members, func(i, j int) bool {
+			return performance.Members[i].Marketcap > performance.Members[j].Marketcap
+		})
+		result = append(result, *performance)
+	}
+
+	service.cache.Set(cacheKey, result, cache.DefaultExpiration)
+	return result, nil
+}
+
+func medianReturn(members []SectorMember) float64 {
+	returns := make([]float64, 0, len(members))
+	for _, member := range members {
+		if member.HasReturn {
+			returns = append(returns, member.Return)
+		}
+	}
+	if len(returns) == 0 {
+		return 0
+	}
+
+	sort.Float64s(returns)
+	middle := len(returns) / 2
+	if len(returns)%2 == 0 {
+		return (returns[middle-1] + returns[middle]) / 2
+	}
+	return returns[middle]
+}
@@ -0,0 +1,185 @@
+package analytics
+
+import (
+	"crypto-analytics/pkg/indicators"
+	cmcService "crypto-analytics/services/coinmarketcap"
+	"crypto-analytics/services/sectors"
+	"crypto-analytics/services/watchlist"
+	"errors"
+
+	"github.com/patrickmn/go-cache"
+)
+
+const (
+	rsiPeriod     = 14
+	rsiOversold   = 30
+	rsiOverbought = 70
+	macdFast      = 12
+	macdSlow      = 26
+	macdSignal    = 9
+	// Bollinger bands are 2 standard deviations around the 20-day SMA.
+	bollingerPeriod     = 20
+	bollingerDeviations = 2
+	volatilityPeriod    = 30
+	// Only the top 1000 is stored every day.
+	rankUniverse = 1000
+	// Peers taken from the sectors of a token without configured competitors.
+	sectorPeersCount = 4
+	// A daily community growth is abnormal when 3 times larger than the average of the last 30 days,
+	// once at least a week of history is known and the move is larger than 1%.
+	communityBaselineDays = 30
+	communityMinSamples   = 7
+	anomalyRatio          = 3
+	anomalyMinGrowth      = 1.0
+)
+
+var (
+	ErrNoHistory   = errors.New("no stored history for this symbol")
+	ErrNoRanking   = errors.New("no stored ranking for this day")
+	ErrNoPeers     = errors.New("no peers for this symbol")
+	ErrNoCommunity = errors.New("no stored community data for this symbol")
+
+	// Top N whose entries and exits are reported.
+	RankThresholds = []int{100, 200}
+)
+
+// TechnicalIndicators are computed on the daily closes stored since the halving.
+// An indicator needing more history than available is left to zero.
+type TechnicalIndicators struct {
+	Symbol string
+	Day    string
+	Price  float64
+	SMA20  float64
+	SMA50  float64
+	SMA200 float64
+	EMA12  float64
+	EMA26  float64
+	RSI    float64
+	MACD   indicators.MACDValue
+	// Bollinger bands of the last 20 days.
+	Bollinger indicators.Bands
+	// Annualized volatility of the last 30 days, as a ratio.
+	Volatility float64
+	// Largest drop since the halving, as a negative ratio.
+	MaxDrawdown float64
+	// Short human readable alerts, e.g. "RLC RSI 28 — oversold".
+	Signals []string
+}
+
+// RankChange compares the rank of a token between two days; a zero rank means outside the top 1000.
+type RankChange struct {
+	Slug         string
+	Symbol       string
+	Name         string
+	Rank         int
+	PreviousRank int
+}
+
+// ThresholdCrossing is a token entering or leaving a top N.
+type ThresholdCrossing struct {
+	RankChange
+	Threshold int
+	Entered   bool
+}
+
+type RankMovement struct {
+	Day   string
+	Since string
+	// Biggest gains and losses of the tokens ranked on both days.
+	Climbers  []RankChange
+	Fallers   []RankChange
+	Crossings []ThresholdCrossing
+}
+
+// SectorMember is a token of a sector with its return over the window, when known.
+type SectorMember struct {
+	Slug      string
+	Symbol    string
+	Name      string
+	Rank      int
+	Marketcap float64
+	Return    float64
+	HasReturn bool
+	Trending  bool
+}
+
+type SectorPerformance struct {
+	Name           string
+	Day            string
+	Since          string
+	TotalMarketcap float64
+	// Change of the market cap of the tokens listed on both days.
+	MarketcapChangePercent float64
+	MedianReturn           float64
+	Trending               int
+	// Sorted by decreasing market cap.
+	Members []SectorMember
+}
+
+// PeerStats is the performance of a token over the window of a comparison.
+type PeerStats struct {
+	Symbol       string
+	Name         string
+	Rank         int
+	PreviousRank int
+	Price        float64
+	Marketcap    float64
+	Return       float64
+	HasReturn    bool
+	// Market cap of the token divided by the one of the compared token.
+	MarketcapRatio float64
+	// Growth in percent of the CMC watch count and followers, when known on both days.
+	WatchCountGrowth    float64
+	HasWatchCountGrowth bool
+	FollowersGrowth     float64
+	HasFollowersGrowth  bool
+	TrendingDays        int
+}
+
+type PeerComparison struct {
+	Day   string
+	Since string
+	// The compared token comes first, then its peers by decreasing market cap.
+	Tokens []PeerStats
+}
+
+// CounterGrowth analyses a CMC community counter; growths are in percent and only set when both days are known.
+type CounterGrowth struct {
+	Value      int64
+	Daily      float64
+	HasDaily   bool
+	Weekly     float64
+	HasWeekly  bool
+	Monthly    float64
+	HasMonthly bool
+	// Daily growth minus the average daily growth of the previous week, in percentage points.
+	Acceleration    float64
+	HasAcceleration bool
+	// Daily growth divided by the average absolute daily growth of the last 30 days; zero on a flat history.
+	NormalRatio float64
+	Anomaly     bool
+}
+
+type CommunityGrowth struct {
+	Symbol     string
+	Day        string
+	Followers  CounterGrowth
+	WatchCount CounterGrowth
+	// Short human readable alerts, e.g. "RLC watchlist +8.0% today, 5x normal".
+	Signals []string
+}
+
+type Service interface {
+	Compute(symbol string, day string) (TechnicalIndicators, error)
+	ComputeRankMovement(day string, days int, limit int) (RankMovement, error)
+	ComputeSectorPerformance(day string, days int) ([]SectorPerformance, error)
+	ComparePeers(symbol string, day string, days int) (PeerComparison, error)
+	ComputeCommunityGrowth(symbol string, day string) (CommunityGrowth, error)
+}
+
+type Impl struct {
+	cmcService       cmcService.Service
+	sectorsService   sectors.Service
+	watchlistService watchlist.Service
+	cache            *cache.Cache
+}
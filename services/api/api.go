@@ -0,0 +1,316 @@
+package api
+
+import (
+	"crypto-analytics/models/entities"
+	communityRepo "crypto-analytics/repositories/community"
+	historicalRepo "crypto-analytics/repositories/historical"
+	marketIndicatorsRepo "crypto-analytics/repositories/marketindicators"
+	trendingRepo "crypto-analytics/repositories/trending"
+	twitterRepo "crypto-analytics/repositories/twitter"
+	"crypto-analytics/services/cryptorank"
+	"crypto-analytics/services/feeds"
+	"crypto-analytics/services/watchlist"
+	"crypto-analytics/utils/dates"
+	"crypto-analytics/utils/insights"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+func New(histoRepo historicalRepo.Repository,
+	trendRepo trendingRepo.Repository,
+	communityRepo communityRepo.Repository,
+	twitterRepo twitterRepo.Repository,
+	indicatorsRepo marketIndicatorsRepo.Repository,
+	cryptorankService cryptorank.Service,
+	watchlistService watchlist.Service,
+	feedsService feeds.Service) *Impl {
+	return &Impl{
+		histoRepo:         histoRepo,
+		trendRepo:         trendRepo,
+		communityRepo:     communityRepo,
+		twitterRepo:       twitterRepo,
+		indicatorsRepo:    indicatorsRepo,
+		cryptorankService: cryptorankService,
+		watchlistService:  watchlistService,
+		feedsService:      feedsService,
+	}
+}
+
+// RegisterRoutes exposes the read-only API next to the probes.
+func (api *Impl) RegisterRoutes(probes insights.Probes) {
+	probes.Handle("GET /api/tokens", http.HandlerFunc(api.tokens))
+	probes.Handle("GET /api/tokens/{symbol}/history", http.HandlerFunc(api.tokenHistory))
+	probes.Handle("GET /api/trending", http.HandlerFunc(api.trending))
+	probes.Handle("GET /api/community/{cid}", http.HandlerFunc(api.community))
+	probes.Handle("GET /api/tweets", http.HandlerFunc(api.tweets))
+	probes.Handle("GET /api/market-indicator", http.HandlerFunc(api.marketIndicator))
+	probes.Handle("GET /api/market-indicator/history", http.HandlerFunc(api.marketIndicatorHistory))
+	probes.Handle("GET /api/news/search", http.HandlerFunc(api.searchNews))
+}
+
+func (api *Impl) tokens(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, api.watchlistService.GetWatchlist())
+}
+
+func (api *Impl) tokenHistory(w http.ResponseWriter, r *http.Request) {
+	from, to, err := parseRange(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	page, limit, err := parsePagination(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	id, err := api.resolveCryptoID(r, strings.ToUpper(r.PathValue("symbol")), to)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		writeJSON(w, http.StatusOK, newPage([]entities.Historical{}, page, limit, 0))
+		return
+	}
+	if errors.Is(err, ErrInvalidParameter) {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	historicals, total, err := api.histoRepo.FetchPageForIDBetween(id, from, to, (page-1)*limit, limit)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newPage(historicals, page, limit, total))
+}
+
+// resolveCryptoID tells which token a symbol stands for, several tokens may share it: the id given by the caller,
+// the one of the watched token, or the best ranked token with this symbol on the last stored day of the range.
+func (api *Impl) resolveCryptoID(r *http.Request, symbol string, to string) (int, error) {
+	if value := r.URL.Query().Get("id"); value != "" {
+		id, err := strconv.Atoi(value)
+		if err != nil || id < 1 {
+			return 0, fmt.Errorf("%w: id must be a CMC id", ErrInvalidParameter)
+		}
+		return id, nil
+	}
+	if token, found := api.watchlistService.FindBySymbol(symbol); found && token.CryptoID > 0 {
+		return token.CryptoID, nil
+	}
+	historical, err := api.histoRepo.FetchLatestForSymbolUntil(symbol, to)
+	if err != nil {
+		return 0, err
+	}
+	return historical.ID, nil
+}
+
+func (api *Impl) trending(w http.ResponseWriter, r *http.Request) {
+	day, err := parseDay(r, "day", time.Now())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	page, limit, err := parsePagination(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	trendingCryptos, total, err := api.trendRepo.FetchPageForDay(day.Format(dates.DateFormat), (page-1)*limit, limit)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newPage(trendingCryptos, page, limit, total))
+}
+
+func (api *Impl) community(w http.ResponseWriter, r *http.Request) {
+	cid, err := strconv.Atoi(r.PathValue("cid"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("%w: cid must be a CMC id", ErrInvalidParameter))
+		return
+	}
+	from, to, err := parseRange(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	page, limit, err := parsePagination(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	communityData, total, err := api.communityRepo.FetchPageBetween(cid, from, to, (page-1)*limit, limit)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newPage(communityData, page, limit, total))
+}
+
+func (api *Impl) tweets(w http.ResponseWriter, r *http.Request) {
+	day, err := parseDay(r, "day", time.Now().AddDate(0, 0, -1))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	page, limit, err := parsePagination(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	start, end := dates.GetDayTimestamps(day)
+	tweets, total, err := api.twitterRepo.FetchPageBetweenTimestamps(start, end, (page-1)*limit, limit)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newPage(tweets, page, limit, total))
+}
+
+func (api *Impl) marketIndicator(w http.ResponseWriter, _ *http.Request) {
+	indicator, err := api.cryptorankService.GetMarketIndicator()
+	if err != nil {
+		writeError(w, http.StatusServiceUnavailable, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, indicator)
+}
+
+func (api *Impl) marketIndicatorHistory(w http.ResponseWriter, r *http.Request) {
+	from, to, err := parseRange(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	page, limit, err := parsePagination(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	fromTime, _ := time.ParseInLocation(dates.DateFormat, from, time.Local)
+	toTime, _ := time.ParseInLocation(dates.DateFormat, to, time.Local)
+	indicators, total, err := api.indicatorsRepo.FetchPageBetween(fromTime, toTime.AddDate(0, 0, 1), (page-1)*limit, limit)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newPage(indicators, page, limit, total))
+}
+
+// searchNews returns the recent articles matching a query, most relevant first, without pagination.
+func (api *Impl) searchNews(w http.ResponseWriter, r *http.Request) {
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	if query == "" {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("%w: q is required", ErrInvalidParameter))
+		return
+	}
+	limit := defaultSearchSize
+	if value := r.URL.Query().Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > maxSearchSize {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidParameter, maxSearchSize))
+			return
+		}
+		limit = parsed
+	}
+
+	items, err := api.feedsService.Search(query, limit)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if items == nil {
+		items = []entities.NewsItem{}
+	}
+
+	writeJSON(w, http.StatusOK, items)
+}
+
+func newPage[T any](data []T, page int, limit int, total int64) Page[T] {
+	if data == nil {
+		data = []T{}
+	}
+	return Page[T]{Data: data, Pagination: Pagination{Page: page, Limit: limit, Total: total}}
+}
+
+func parsePagination(r *http.Request) (int, int, error) {
+	page, limit := 1, defaultPageSize
+	if value := r.URL.Query().Get("page"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 {
+			return 0, 0, fmt.Errorf("%w: page must be a positive integer", ErrInvalidParameter)
+		}
+		page = parsed
+	}
+	if value := r.URL.Query().Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > maxPageSize {
+			return 0, 0, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidParameter, maxPageSize)
+		}
+		limit = parsed
+	}
+	return page, limit, nil
+}
+
+// parseRange reads the from/to days, defaulting to the last defaultHistoryDays days.
+func parseRange(r *http.Request) (string, string, error) {
+	to, err := parseDay(r, "to", time.Now())
+	if err != nil {
+		return "", "", err
+	}
+	from, err := parseDay(r, "from", to.AddDate(0, 0, -defaultHistoryDays))
+	if err != nil {
+		return "", "", err
+	}
+	if from.After(to) {
+		return "", "", fmt.Errorf("%w: from must be before to", ErrInvalidParameter)
+	}
+	return from.Format(dates.DateFormat), to.Format(dates.DateFormat), nil
+}
+
+func parseDay(r *http.Request, name string, fallback time.Time) (time.Time, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return fallback, nil
+	}
+	day, err := time.ParseInLocation(dates.DateFormat, value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %s must be formatted as YYYY-MM-DD", ErrInvalidParameter, name)
+	}
+	return day, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		log.Error().Err(err).Msgf("Cannot encode JSON response")
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	if status >= http.StatusInternalServerError {
+		log.Error().Err(err).Msg("API request failed")
+	}
+	writeJSON(w, status, ErrorResponse{Error: err.Error()})
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	communityRepo "crypto-analytics/repositories/community"
+	historicalRepo "crypto-analytics/repositories/historical"
+	marketIndicatorsRepo "crypto-analytics/repositories/marketindicators"
+	trendingRepo "crypto-analytics/repositories/trending"
+	twitterRepo "crypto-analytics/repositories/twitter"
+	"crypto-analytics/services/cryptorank"
+	"crypto-analytics/services/feeds"
+	"crypto-analytics/services/watchlist"
+	"errors"
+)
+
+const (
+	defaultPageSize = 100
+	maxPageSize     = 1000
+	// Range served when the caller omits from/to.
+	defaultHistoryDays = 30
+	// Articles returned by a news search, ranked by relevance.
+	defaultSearchSize = 20
+	maxSearchSize     = 100
+)
+
+var (
+	ErrInvalidParameter = errors.New("invalid parameter")
+)
+
+type Page[T any] struct {
+	Data       []T        `json:"data"`
+	Pagination Pagination `json:"pagination"`
+}
+
+type Pagination struct {
+	Page  int   `json:"page"`
+	Limit int   `json:"limit"`
+	Total int64 `json:"total"`
+}
+
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+type Impl struct {
+	histoRepo         historicalRepo.Repository
+	trendRepo         trendingRepo.Repository
+	communityRepo     communityRepo.Repository
+	twitterRepo       twitterRepo.Repository
+	indicatorsRepo    marketIndicatorsRepo.Repository
+	cryptorankService cryptorank.Service
+	watchlistService  watchlist.Service
+	feedsService      feeds.Service
+}
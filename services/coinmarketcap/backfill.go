@@ -0,0 +1,143 @@
+package coinmarketcap
+
+import (
+	"crypto-analytics/models/entities"
+	"crypto-analytics/pkg/observer"
+	"crypto-analytics/utils/dates"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+type backfillPage struct {
+	day   string
+	start int
+}
+
+// backfillHistorical fetches every listing page missing between the halving and
+// yesterday. The plan is computed from stored data, so an interrupted run
+// naturally resumes where it stopped.
+func (service *Impl) backfillHistorical() error {
+	to := time.Now().AddDate(0, 0, -1).Format(dates.DateFormat)
+	progress, err := service.Backfill(HalvingDate, to, false)
+	if errors.Is(err, ErrBackfillRunning) {
+		return nil
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("Cannot plan historical backfill")
+		return err
+	}
+	if progress.FailedPages > 0 {
+		return fmt.Errorf("%d historical pages failed, recorded to be retried", progress.FailedPages)
+	}
+	return nil
+}
+
+// Backfill fetches the listing pages missing between two days, both included.
+// It is refused while another process runs one, unless forced, e.g. after a crash within the lease timeout.
+func (service *Impl) Backfill(from string, to string, force bool) (entities.BackfillProgress, error) {
+	if !service.backfillMutex.TryLock() {
+		log.Warn().Msg("Historical backfill already running, skipped")
+		return entities.BackfillProgress{}, ErrBackfillRunning
+	}
+	defer service.backfillMutex.Unlock()
+
+	plan, err := service.planHistoricalBackfill(from, to)
+	if err != nil {
+		return entities.BackfillProgress{}, err
+	}
+
+	previous, errPrevious := service.backfillRepo.Get()
+	now := time.Now().UTC()
+	progress := entities.BackfillProgress{From: from, To: to, PlannedPages: len(plan), Running: true, StartedAt: now, UpdatedAt: now}
+	if force {
+		service.saveBackfillProgress(progress)
+	} else {
+		acquired, errAcquire := service.backfillRepo.Acquire(progress, now.Add(-backfillLeaseTimeout))
+		if errAcquire != nil {
+			return entities.BackfillProgress{}, errAcquire
+		}
+		if !acquired {
+			log.Warn().Msg("Historical backfill running in another process, skipped")
+			return entities.BackfillProgress{}, ErrBackfillRunning
+		}
+	}
+	if errPrevious == nil && previous.Running {
+		log.Warn().Int("done", previous.DonePages).Int("planned", previous.PlannedPages).Msg("Resuming interrupted historical backfill")
+	}
+	log.Info().Str("from", from).Str("to", to).Int("pages", len(plan)).Msg("Start historical backfill")
+
+	for i, page := range plan {
+		if service.fetchAndSaveHistoricalPage(page.day, page.start) != nil {
+			progress.FailedPages++
+		} else {
+			progress.DonePages++
+		}
+		progress.UpdatedAt = time.Now().UTC()
+		service.saveBackfillProgress(progress)
+
+		if i == len(plan)-1 || plan[i+1].day != page.day {
+			log.Info().Str("day", page.day).
+				Int("done", progress.DonePages).Int("failed", progress.FailedPages).Int("planned", progress.PlannedPages).
+				Msg("Historical backfill progress")
+		}
+	}
+
+	progress.Running = false
+	progress.FinishedAt = time.Now().UTC()
+	progress.UpdatedAt = progress.FinishedAt
+	service.saveBackfillProgress(progress)
+	log.Info().Int("done", progress.DonePages).Int("failed", progress.FailedPages).Msg("End historical backfill")
+
+	if progress.DonePages > 0 {
+		service.notify(observer.Event{E: observer.RankingEvent})
+	}
+
+	return progress, nil
+}
+
+// planHistoricalBackfill lists the (day, page) pairs without enough stored rows.
+func (service *Impl) planHistoricalBackfill(from string, to string) ([]backfillPage, error) {
+	storedPages, err := service.histoRepo.FetchPagesBetween(from, to, limitDataPerCall)
+	if err != nil {
+		return nil, err
+	}
+
+	complete := make(map[string]map[int]bool)
+	for _, page := range storedPages {
+		if page.Count < minRowsPerCompletePage {
+			continue
+		}
+		if complete[page.Day] == nil {
+			complete[page.Day] = make(map[int]bool)
+		}
+		complete[page.Day][page.Page] = true
+	}
+
+	var plan []backfillPage
+	for _, day := range dates.GenerateDatesBetween2Dates(from, to, dates.DateFormat) {
+		for page := 0; page < historicalPagesPerDay; page++ {
+			if !complete[day][page] {
+				plan = append(plan, backfillPage{day: day, start: page*limitDataPerCall + 1})
+			}
+		}
+	}
+
+	return plan, nil
+}
+
+func (service *Impl) GetBackfillProgress() entities.BackfillProgress {
+	progress, err := service.backfillRepo.Get()
+	if err != nil {
+		return entities.BackfillProgress{}
+	}
+	return progress
+}
+
+func (service *Impl) saveBackfillProgress(progress entities.BackfillProgress) {
+	if err := service.backfillRepo.Save(progress); err != nil {
+		log.Error().Err(err).Msg("Cannot save historical backfill progress")
+	}
+}
@@ -5,14 +5,22 @@ import (
 	"crypto-analytics/models/constants"
 	"crypto-analytics/models/entities"
 	"crypto-analytics/pkg/observer"
+	backfillRepo "crypto-analytics/repositories/backfill"
 	communityRepo "crypto-analytics/repositories/community"
 	historicalRepo "crypto-analytics/repositories/historical"
+	failuresRepo "crypto-analytics/repositories/historicalfailures"
+	quotesRepo "crypto-analytics/repositories/quotes"
+	tokenTagsRepo "crypto-analytics/repositories/tokentags"
 	trendingRepo "crypto-analytics/repositories/trending"
+	"crypto-analytics/services/sectors"
+	"crypto-analytics/services/watchlist"
 	"crypto-analytics/utils/dates"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
-	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-co-op/gocron/v2"
@@ -21,18 +29,17 @@ import (
 )
 
 func New(scheduler gocron.Scheduler,
+	client *http.Client,
 	trending trendingRepo.Repository,
 	historical historicalRepo.Repository,
-	community communityRepo.Repository) (*Impl, error) {
-	service := &Impl{
-		baseURL: cmcBaseAPI,
-		client: &http.Client{
-			Timeout: clientHTTPTimeout,
-		},
-		trendRepo:     trending,
-		histoRepo:     historical,
-		communityRepo: community,
-	}
+	community communityRepo.Repository,
+	quotes quotesRepo.Repository,
+	failures failuresRepo.Repository,
+	backfill backfillRepo.Repository,
+	tokenTags tokenTagsRepo.Repository,
+	watchlistService watchlist.Service,
+	sectorsService sectors.Service) (*Impl, error) {
+	service := NewService(client, trending, historical, community, quotes, failures, backfill, tokenTags, watchlistService, sectorsService)
 
 	if viper.GetBool(constants.Production) {
 		service.FetchAndSaveTrendingCrypto()
@@ -43,13 +50,10 @@ func New(scheduler gocron.Scheduler,
 		}
 
 	}
-	if service.histoRepo.Count() == 0 {
-		service.fetchAndSaveHistoricalSinceHalving()
-	}
 
 	_, errTrendingJob := scheduler.NewJob(
 		gocron.CronJob(viper.GetString(constants.TrendingCryptoCronTab), true),
-		gocron.NewTask(func() { service.FetchAndSaveTrendingCrypto() }),
+		gocron.NewTask(func() error { return service.FetchAndSaveTrendingCrypto() }),
 		gocron.WithName("Fetch trending crypto"),
 	)
 	if errTrendingJob != nil {
@@ -58,63 +62,223 @@ func New(scheduler gocron.Scheduler,
 
 	_, errHistoricalJob := scheduler.NewJob(
 		gocron.CronJob(viper.GetString(constants.HistoricalCryptoCronTab), true),
-		gocron.NewTask(func() { service.fetchAndSaveHistorical() }),
+		gocron.NewTask(func() error { return service.fetchAndSaveHistorical() }),
 		gocron.WithName("Fetch historical crypto"),
 	)
 	if errHistoricalJob != nil {
 		return nil, errHistoricalJob
 	}
 
+	// In production, the gaps are also filled once the scheduler starts, after every observer is registered.
+	backfillOptions := []gocron.JobOption{gocron.WithName("Backfill historical crypto")}
+	if viper.GetBool(constants.Production) {
+		backfillOptions = append(backfillOptions, gocron.WithStartAt(gocron.WithStartImmediately()))
+	}
+	_, errBackfillJob := scheduler.NewJob(
+		gocron.CronJob(viper.GetString(constants.HistoricalBackfillCronTab), true),
+		gocron.NewTask(func() error { return service.backfillHistorical() }),
+		backfillOptions...,
+	)
+	if errBackfillJob != nil {
+		return nil, errBackfillJob
+	}
+
+	_, errRetryJob := scheduler.NewJob(
+		gocron.CronJob(viper.GetString(constants.HistoricalRetryCronTab), true),
+		gocron.NewTask(func() error { return service.retryFailedHistorical() }),
+		gocron.WithName("Retry failed historical crypto"),
+	)
+	if errRetryJob != nil {
+		return nil, errRetryJob
+	}
+
 	_, errCommunityData := scheduler.NewJob(
 		gocron.CronJob("0 * * * *", true),
-		gocron.NewTask(func() { service.fetchAndSaveCommunityData(false) }),
+		gocron.NewTask(func() error { return service.fetchAndSaveCommunityData(false) }),
 		gocron.WithName("Fetch community data"),
 	)
 	if errCommunityData != nil {
 		return nil, errCommunityData
 	}
 
-	service.observers = map[observer.Observer]struct{}{}
+	_, errQuotesJob := scheduler.NewJob(
+		gocron.CronJob(viper.GetString(constants.IntradayQuoteCronTab), true),
+		gocron.NewTask(func() error { return service.fetchAndSaveQuotes() }),
+		gocron.WithName("Fetch intraday quotes"),
+	)
+	if errQuotesJob != nil {
+		return nil, errQuotesJob
+	}
 
 	return service, nil
 }
 
+// NewService builds the service without scheduling any job nor fetching anything,
+// for one-off usages such as the command line.
+func NewService(client *http.Client,
+	trending trendingRepo.Repository,
+	historical historicalRepo.Repository,
+	community communityRepo.Repository,
+	quotes quotesRepo.Repository,
+	failures failuresRepo.Repository,
+	backfill backfillRepo.Repository,
+	tokenTags tokenTagsRepo.Repository,
+	watchlistService watchlist.Service,
+	sectorsService sectors.Service) *Impl {
+	return &Impl{
+		baseURL:       viper.GetString(constants.CoinMarketCapBaseURL),
+		client:        client,
+		trendRepo:     trending,
+		histoRepo:     historical,
+		communityRepo: community,
+		quotesRepo:    quotes,
+		failuresRepo:  failures,
+		backfillRepo:  backfill,
+		tokenTagsRepo: tokenTags,
+		watchlist:     watchlistService,
+		sectors:       sectorsService,
+		observers:     map[observer.Observer]struct{}{},
+		moversFilter: MoversFilter{
+			MinMarketcap: viper.GetFloat64(constants.MoversMinMarketcap),
+			MaxRank:      viper.GetInt(constants.MoversMaxRank),
+		},
+	}
+}
+
 func (service *Impl) RegisterObserver(o observer.Observer) {
+	service.observersMutex.Lock()
+	defer service.observersMutex.Unlock()
 	service.observers[o] = struct{}{}
 }
 
 func (service *Impl) notify(e observer.Event) {
+	service.observersMutex.RLock()
+	defer service.observersMutex.RUnlock()
 	for o := range service.observers {
 		o.OnNotify(e)
 	}
 }
 
-func (service *Impl) fetchAndSaveHistoricalSinceHalving() {
-	log.Info().Msg("Start fetching historical crypto since halving")
-	from, _ := dates.StringToDate(halvingDate, dates.DateFormat)
-	rangeDates := dates.GenerateDatesBetweenTwoDates(from, time.Now())
-	var startSteps = []int{1, 201, 401, 601, 801}
+// fetchAndSaveHistoricalPage stores one page of a historical listing; a failed
+// page is recorded so that retryFailedHistorical can fill the hole later on.
+func (service *Impl) fetchAndSaveHistoricalPage(day string, start int) error {
+	data, err := service.fetchHistoricalPaginate(day, start, limitDataPerCall)
+	if err != nil {
+		log.Error().Err(err).Str("day", day).Int("start", start).Msg("failed to fetch historical page")
+		service.recordHistoricalFailure(day, start, err)
+		return err
+	}
 
-	for _, date := range rangeDates {
-		log.Info().Time("date", date).Msg("fetching for date")
-		for _, start := range startSteps {
-			data, err := service.fetchHistoricalPaginate(date.Format(dates.DateFormat), start, limitDataPerCall)
-			if err != nil {
-				continue
-			}
-			for _, d := range data.CryptoCurrencies {
-				crypto := entities.Historical{ID: d.ID, Rank: d.CmcRank, Slug: d.Slug, Tags: d.KeepOnlyRelevantsTags(), Name: d.Name, Symbol: d.Symbol, Day: date.Format(dates.DateFormat), Price: d.Quotes[0].Price, Marketcap: d.Quotes[0].MaketCap}
-				service.histoRepo.Save(crypto)
-			}
-			time.Sleep(delayBetweenCall)
+	tags := make(map[string][]string, len(data.CryptoCurrencies))
+	var errSave error
+	unsaved := 0
+	for _, d := range data.CryptoCurrencies {
+		quote, ok := d.USDQuote()
+		if !ok {
+			continue
+		}
+		crypto := entities.Historical{ID: d.ID, Rank: d.CmcRank, Slug: d.Slug, Tags: strings.Join(service.sectors.RelevantTags(d.Tags), ";"), Name: d.Name, Symbol: d.Symbol, Day: day, Price: quote.Price, Marketcap: quote.MaketCap}
+		if errCrypto := service.histoRepo.Save(crypto); errCrypto != nil {
+			errSave = errCrypto
+			unsaved++
+			continue
+		}
+		tags[d.Slug] = normalizeTags(d.Tags)
+	}
+	if errTags := service.tokenTagsRepo.SaveForDay(day, tags); errTags != nil {
+		errSave = errTags
+	}
+	// A page partly saved is recorded as failed, to be fetched again instead of leaving a hole.
+	if errSave != nil {
+		err = fmt.Errorf("%d rows not saved: %w", unsaved, errSave)
+		log.Error().Err(err).Str("day", day).Int("start", start).Msg("failed to save historical page")
+		service.recordHistoricalFailure(day, start, err)
+		return err
+	}
+
+	if errDelete := service.failuresRepo.Delete(day, start); errDelete != nil {
+		log.Error().Err(errDelete).Str("day", day).Int("start", start).Msg("failed to clear historical failure")
+	}
+	return nil
+}
+
+// normalizeTags keeps every tag of a token once, the sectors being matched when read.
+func normalizeTags(rawTags []string) []string {
+	tags := make([]string, 0, len(rawTags))
+	seen := make(map[string]bool, len(rawTags))
+	for _, tag := range rawTags {
+		tag = sectors.NormalizeTag(tag)
+		if tag != "" && !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+func (service *Impl) recordHistoricalFailure(day string, start int, cause error) {
+	failure, err := service.failuresRepo.Find(day, start)
+	if err != nil {
+		failure = entities.HistoricalFailure{Day: day, Start: start}
+	}
+	failure.Attempts++
+	failure.LastError = cause.Error()
+	failure.LastAttempt = time.Now().UTC()
+
+	if errSave := service.failuresRepo.Save(failure); errSave != nil {
+		log.Error().Err(errSave).Str("day", day).Int("start", start).Msg("failed to record historical failure")
+	}
+}
+
+func (service *Impl) retryFailedHistorical() error {
+	failures, err := service.failuresRepo.FetchAll()
+	if err != nil {
+		log.Error().Err(err).Msg("failed to fetch historical failures")
+		return err
+	}
+
+	now := time.Now().UTC()
+	var due []entities.HistoricalFailure
+	for _, failure := range failures {
+		if isHistoricalRetryDue(failure, now) {
+			due = append(due, failure)
 		}
 	}
-	log.Info().Msg("End fetching historical crypto since halving")
+	if len(due) == 0 {
+		return nil
+	}
+
+	log.Info().Int("due", len(due)).Int("failures", len(failures)).Msg("Start retrying failed historical fetches")
+	recovered := 0
+	for _, failure := range due {
+		if service.fetchAndSaveHistoricalPage(failure.Day, failure.Start) == nil {
+			recovered++
+		}
+	}
+	log.Info().Int("recovered", recovered).Int("remaining", len(due)-recovered).Msg("End retrying failed historical fetches")
+
+	if recovered > 0 {
+		service.notify(observer.Event{E: observer.RankingEvent})
+	}
+	if recovered < len(due) {
+		return fmt.Errorf("%d historical pages still failing", len(due)-recovered)
+	}
+	return nil
+}
+
+// isHistoricalRetryDue backs off exponentially on the attempts of a failed page, given up after maxHistoricalAttempts;
+// the backfill still plans it again as long as it is missing.
+func isHistoricalRetryDue(failure entities.HistoricalFailure, now time.Time) bool {
+	if failure.Attempts >= maxHistoricalAttempts {
+		return false
+	}
+	delay := historicalRetryBackoff << max(failure.Attempts-1, 0)
+	return !now.Before(failure.LastAttempt.Add(delay))
 }
 
 func (service *Impl) fetchWatcherData(cryptoID int) (*LiteResponse, error) {
 	endpoint := fmt.Sprintf("%s/data-api/v3/cryptocurrency/detail/lite?id=%v", service.baseURL, cryptoID)
-	resp, err := http.Get(endpoint)
+	resp, err := service.client.Get(endpoint)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch data: %w", err)
 	}
@@ -132,31 +296,74 @@ func (service *Impl) fetchWatcherData(cryptoID int) (*LiteResponse, error) {
 	return &result, nil
 }
 
-func (service *Impl) fetchAndSaveCommunityData(first bool) {
+func (service *Impl) fetchAndSaveCommunityData(first bool) error {
 	log.Info().Msg("Start fetching community data")
-	cryptocurrencies := constants.GetCrytoWatch()
+	cryptocurrencies := append(service.watchlist.GetWatchlist(), service.peerTokens()...)
 	day := time.Now().Format(dates.DateFormat)
 	if first {
 		day = time.Now().AddDate(0, 0, -1).Format(dates.DateFormat)
 	}
+	failed := 0
 	for _, cryptoccryptocurrency := range cryptocurrencies {
 		log.Info().Str("symbol", cryptoccryptocurrency.Symbol).Msg("Fetching community data")
-		entity := entities.CommunityData{Cid: cryptoccryptocurrency.CryptoId, Symbol: cryptoccryptocurrency.Symbol, Day: day, Followers: "0", WatchCount: "0"}
-		profileData, errProfile := service.fetchProfileData(cryptoccryptocurrency.Handle)
-		watchData, errWatch := service.fetchWatcherData(cryptoccryptocurrency.CryptoId)
-		if errProfile == nil {
-			entity.Followers = profileData.Data.Account.Followers
+		entity := entities.CommunityData{Cid: cryptoccryptocurrency.CryptoID, Symbol: cryptoccryptocurrency.Symbol, Day: day}
+		watchData, errWatch := service.fetchWatcherData(cryptoccryptocurrency.CryptoID)
+		if cryptoccryptocurrency.Handle != "" {
+			profileData, errProfile := service.fetchProfileData(cryptoccryptocurrency.Handle)
+			if errProfile == nil {
+				entity.Followers = parseCounter(profileData.Data.Account.Followers)
+			}
 		}
 		if errWatch == nil {
-			entity.WatchCount = watchData.Data.WatchCount
+			entity.WatchCount = parseCounter(watchData.Data.WatchCount)
+		} else {
+			log.Error().Err(errWatch).Str("symbol", cryptoccryptocurrency.Symbol).Msg("Cannot fetch watch count")
+			failed++
 		}
 		err := service.communityRepo.Save(entity)
 		if err != nil {
 			log.Error().Err(err).Str("symbol", cryptoccryptocurrency.Symbol).Msg("Fetching community data")
+			failed++
 		}
 	}
-	log.Info().Msg("End fetching community data")
+	log.Info().Int("failed", failed).Msg("End fetching community data")
 	service.notify(observer.Event{E: observer.RankingEvent})
+	if failed > 0 {
+		return fmt.Errorf("community data incomplete for %d tokens", failed)
+	}
+	return nil
+}
+
+// parseCounter reads a community counter returned as a string by CMC, e.g. "75311" or "1,234"; unknown values are 0.
+func parseCounter(value string) int64 {
+	counter, err := strconv.ParseInt(strings.ReplaceAll(strings.TrimSpace(value), ",", ""), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return counter
+}
+
+// peerTokens resolves the CMC ids of the competitors outside the watchlist from the latest listing;
+// their handle being unknown, only their watch count is followed.
+func (service *Impl) peerTokens() []entities.WatchedToken {
+	latestDay, err := service.histoRepo.FetchLatestDay()
+	if err != nil {
+		return nil
+	}
+
+	var tokens []entities.WatchedToken
+	for _, symbol := range service.watchlist.GetAllPeers() {
+		if _, watched := service.watchlist.FindBySymbol(symbol); watched {
+			continue
+		}
+		historicals, errFetch := service.FetchForSymbolBetween(symbol, latestDay, latestDay)
+		if errFetch != nil || len(historicals) == 0 || historicals[0].ID == 0 {
+			log.Warn().Str("symbol", symbol).Msg("Unknown peer, community data skipped")
+			continue
+		}
+		tokens = append(tokens, entities.WatchedToken{CryptoID: historicals[0].ID, Symbol: symbol})
+	}
+	return tokens
 }
 
 func (service *Impl) fetchProfileData(handle string) (*ProfileResponse, error) {
@@ -169,7 +376,7 @@ func (service *Impl) fetchProfileData(handle string) (*ProfileResponse, error) {
 		return nil, fmt.Errorf("failed to prepared data: %w", err)
 	}
 
-	resp, err := http.Post(endpoint, "application/json", bytes.NewBuffer(jsonValue))
+	resp, err := service.client.Post(endpoint, "application/json", bytes.NewBuffer(jsonValue))
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch data: %w", err)
 	}
@@ -220,23 +427,17 @@ func (service *Impl) fetchProfileData(handle string) (*ProfileResponse, error) {
 
 *
 */
-func (service *Impl) fetchAndSaveHistorical() {
+func (service *Impl) fetchAndSaveHistorical() error {
 	log.Info().Msg("Start fetching historical crypto")
 	var startSteps = []int{1, 201, 401, 601, 801}
 	yesterday := time.Now().AddDate(0, 0, -1).Format(dates.DateFormat)
+	var errs []error
 	for _, start := range startSteps {
-		data, err := service.fetchHistoricalPaginate(yesterday, start, limitDataPerCall)
-		if err != nil {
-			continue
-		}
-		for _, d := range data.CryptoCurrencies {
-			crypto := entities.Historical{ID: d.ID, Rank: d.CmcRank, Tags: d.KeepOnlyRelevantsTags(), Slug: d.Slug, Name: d.Name, Symbol: d.Symbol, Day: yesterday, Price: d.Quotes[0].Price, Marketcap: d.Quotes[0].MaketCap}
-			service.histoRepo.Save(crypto)
-		}
-		time.Sleep(delayBetweenCall)
+		errs = append(errs, service.fetchAndSaveHistoricalPage(yesterday, start))
 	}
 	service.notify(observer.Event{E: observer.RankingEvent})
 	log.Info().Msg("End fetching historical crypto")
+	return errors.Join(errs...)
 }
 
 func (service *Impl) fetchHistoricalPaginate(date string, start int, limit int) (*HistoricalResponse, error) {
@@ -245,7 +446,7 @@ func (service *Impl) fetchHistoricalPaginate(date string, start int, limit int)
 	url := fmt.Sprintf("%s?convertId=%s&date=%s&limit=%d&start=%d",
 		endpoint, convertIDs, date, limit, start)
 
-	resp, err := http.Get(url)
+	resp, err := service.client.Get(url)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch data: %w", err)
 	}
@@ -263,27 +464,27 @@ func (service *Impl) fetchHistoricalPaginate(date string, start int, limit int)
 	return &result, nil
 }
 
-func (service *Impl) FetchAndSaveTrendingCrypto() {
+func (service *Impl) FetchAndSaveTrendingCrypto() error {
 	log.Info().Msg("Start fetching trending crypto")
 
 	url := fmt.Sprintf("%s/data-api/v3/cryptocurrency/listing?start=1&limit=50&sortBy=trending_24h&sortType=desc&cryptoType=all&tagType=all&audited=false", service.baseURL)
-	resp, err := http.Get(url)
+	resp, err := service.client.Get(url)
 	if err != nil {
 		log.Error().Err(err).Msg("failed to make API request")
-		return
+		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		log.Error().Msgf("API request failed with status: %d", resp.StatusCode)
-		return
+		return fmt.Errorf("API request failed with status: %d", resp.StatusCode)
 	}
 
 	var trendingResponse TrendingResponse
 	err = json.NewDecoder(resp.Body).Decode(&trendingResponse)
 	if err != nil {
 		log.Error().Err(err).Msg("failed to decode JSON response")
-		return
+		return err
 	}
 
 	today := time.Now().Format(dates.DateFormat)
@@ -295,33 +496,295 @@ func (service *Impl) FetchAndSaveTrendingCrypto() {
 	}
 	service.notify(observer.Event{E: observer.TrendingEvent})
 	log.Info().Msg("End fetching trending crypto")
+	return nil
+}
+
+// fetchAndSaveQuotes quotes the followed tokens from the top of the listing, the ones ranked below one by one.
+func (service *Impl) fetchAndSaveQuotes() error {
+	log.Info().Msg("Start fetching intraday quotes")
+	quoted := service.quotedTokens()
+	if len(quoted) == 0 {
+		return nil
+	}
+
+	url := fmt.Sprintf("%s/data-api/v3/cryptocurrency/listing?start=1&limit=%d&sortBy=market_cap&sortType=desc&convert=%s&cryptoType=all&tagType=all&audited=false",
+		service.baseURL, limitQuotesCall, usdQuoteName)
+	resp, err := service.client.Get(url)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to make API request")
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		log.Error().Msgf("API request failed with status: %d", resp.StatusCode)
+		return fmt.Errorf("API request failed with status: %d", resp.StatusCode)
+	}
+
+	var listingResponse TrendingResponse
+	err = json.NewDecoder(resp.Body).Decode(&listingResponse)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to decode JSON response")
+		return err
+	}
+
+	service.quotesBeat.Beat()
+	now := time.Now().UTC()
+	saved := 0
+	var errs []error
+	for _, d := range listingResponse.Data.CryptoCurrencies {
+		if _, found := quoted[d.ID]; !found {
+			continue
+		}
+		quote, ok := d.USDQuote()
+		if !ok {
+			continue
+		}
+		delete(quoted, d.ID)
+		errSave := service.quotesRepo.Save(entities.Quote{
+			CryptoID: d.ID, Symbol: d.Symbol, Timestamp: now, Price: quote.Price, Marketcap: quote.MaketCap,
+			Volume24h: quote.Volume24h, PercentChange24h: quote.PercentChange24h,
+		})
+		if errSave != nil {
+			log.Error().Err(errSave).Str("symbol", d.Symbol).Msg("failed to save quote")
+			errs = append(errs, errSave)
+			continue
+		}
+		saved++
+	}
+	for cryptoID, symbol := range quoted {
+		if errQuote := service.fetchAndSaveQuoteByID(cryptoID, symbol, now); errQuote != nil {
+			log.Error().Err(errQuote).Int("cryptoID", cryptoID).Str("symbol", symbol).Msg("failed to quote token outside the listing")
+			errs = append(errs, errQuote)
+			continue
+		}
+		saved++
+	}
+
+	deleted, errDelete := service.quotesRepo.DeleteOlderThan(now.Add(-viper.GetDuration(constants.IntradayQuoteRetention)))
+	if errDelete != nil {
+		log.Error().Err(errDelete).Msg("failed to delete old quotes")
+		errs = append(errs, errDelete)
+	}
+
+	log.Info().Int("saved", saved).Int64("deleted", deleted).Msg("End fetching intraday quotes")
+	if saved > 0 {
+		service.notify(observer.Event{E: observer.PriceEvent})
+	}
+	return errors.Join(errs...)
+}
+
+// fetchAndSaveQuoteByID quotes a token ranked below the listing from its detail.
+func (service *Impl) fetchAndSaveQuoteByID(cryptoID int, symbol string, now time.Time) error {
+	detail, err := service.fetchWatcherData(cryptoID)
+	if err != nil {
+		return err
+	}
+	if detail.Data.Statistics.Price <= 0 {
+		return fmt.Errorf("no price in the detail of %s", symbol)
+	}
+	return service.quotesRepo.Save(entities.Quote{
+		CryptoID: cryptoID, Symbol: symbol, Timestamp: now, Price: detail.Data.Statistics.Price,
+		Marketcap: detail.Data.Statistics.MarketCap, PercentChange24h: detail.Data.Statistics.PriceChangePercentage24h,
+	})
+}
+
+// AddQuotedSymbols quotes the symbols of a source intraday too, to be called before the scheduler starts.
+func (service *Impl) AddQuotedSymbols(source SymbolsSource) {
+	service.quotedSymbols = append(service.quotedSymbols, source)
+}
+
+// quotedTokens returns the symbols to quote by CMC id: the watchlist and the symbols of the sources.
+func (service *Impl) quotedTokens() map[int]string {
+	quoted := make(map[int]string)
+	for _, token := range service.watchlist.GetWatchlist() {
+		if token.CryptoID > 0 {
+			quoted[token.CryptoID] = token.Symbol
+		}
+	}
+
+	today := time.Now().Format(dates.DateFormat)
+	for _, source := range service.quotedSymbols {
+		symbols, err := source()
+		if err != nil {
+			log.Error().Err(err).Msg("Cannot list the symbols to quote")
+			continue
+		}
+		for _, symbol := range symbols {
+			if _, watched := service.watchlist.FindBySymbol(symbol); watched {
+				continue
+			}
+			cryptoID, found := service.resolveCryptoID(symbol, today)
+			if !found {
+				log.Warn().Str("symbol", symbol).Msg("Unknown symbol, not quoted")
+				continue
+			}
+			quoted[cryptoID] = symbol
+		}
+	}
+	return quoted
+}
+
+// FetchLiveQuote returns the latest intraday quote of a symbol if it is still fresh.
+func (service *Impl) FetchLiveQuote(symbol string) (entities.Quote, error) {
+	quote, err := service.quotesRepo.FetchLatestForSymbol(symbol)
+	if err != nil {
+		return quote, err
+	}
+	if time.Since(quote.Timestamp) > quoteFreshness {
+		return quote, ErrNoLiveQuote
+	}
+	return quote, nil
 }
 
 func (service *Impl) IsCryptoTrendyYersterday(symbol string) bool {
 	yesterday := time.Now().AddDate(0, 0, -1).Format(dates.DateFormat)
-	v, err := service.trendRepo.IsCryptoTrendyAtDay(symbol, yesterday)
+
+	return service.IsCryptoTrendyAtDay(symbol, yesterday)
+}
+
+func (service *Impl) IsCryptoTrendyAtDay(symbol string, day string) bool {
+	v, err := service.trendRepo.IsCryptoTrendyAtDay(symbol, day)
 	if err != nil || v.Name == "" {
 		return false
 	}
 	return true
 }
 
+// FetchForSymbolForDay looks a watched symbol up by its CMC id, several tokens may share a symbol;
+// the best ranked token with this symbol is returned otherwise.
+func (service *Impl) FetchForSymbolForDay(symbol string, day string) (entities.Historical, error) {
+	if token, found := service.watchlist.FindBySymbol(symbol); found && token.CryptoID > 0 {
+		return service.histoRepo.FetchForIDForDay(token.CryptoID, day)
+	}
+	return service.histoRepo.FetchForSymbolForDay(symbol, day)
+}
+
+func (service *Impl) FetchForIDForDay(id int, day string) (entities.Historical, error) {
+	return service.histoRepo.FetchForIDForDay(id, day)
+}
+
+// FetchForSymbolBetween returns one snapshot per day of a single token, several tokens may share the symbol:
+// a watched symbol is looked up by its CMC id, another one by the id of the best ranked token on the last stored day.
+func (service *Impl) FetchForSymbolBetween(symbol string, from string, to string) ([]entities.Historical, error) {
+	var historicals []entities.Historical
+	var err error
+	if id, found := service.resolveCryptoID(symbol, to); found {
+		historicals, err = service.histoRepo.FetchForIDBetween(id, from, to)
+	} else {
+		historicals, err = service.histoRepo.FetchForSymbolBetween(symbol, from, to)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]entities.Historical, 0, len(historicals))
+	for _, historical := range historicals {
+		last := len(result) - 1
+		if last >= 0 && result[last].Day == historical.Day {
+			if historical.Rank > 0 && (result[last].Rank == 0 || historical.Rank < result[last].Rank) {
+				result[last] = historical
+			}
+			continue
+		}
+		result = append(result, historical)
+	}
+
+	return result, nil
+}
+
+// resolveCryptoID returns the CMC id of the watched token with this symbol, or of the best ranked one on the latest day up to the given one.
+func (service *Impl) resolveCryptoID(symbol string, day string) (int, bool) {
+	if token, found := service.watchlist.FindBySymbol(symbol); found && token.CryptoID > 0 {
+		return token.CryptoID, true
+	}
+	historical, err := service.histoRepo.FetchLatestForSymbolUntil(symbol, day)
+	if err != nil || historical.ID == 0 {
+		return 0, false
+	}
+	return historical.ID, true
+}
+
+// FetchTokenTags returns the tags of every token at a day, by slug.
+func (service *Impl) FetchTokenTags(day string) (map[string][]string, error) {
+	tokenTags, err := service.tokenTagsRepo.FetchForDay(day)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make(map[string][]string)
+	for _, tokenTag := range tokenTags {
+		tags[tokenTag.Slug] = append(tags[tokenTag.Slug], tokenTag.Tag)
+	}
+	return tags, nil
+}
+
+func (service *Impl) FetchTrendingForDay(day string) ([]entities.TrendingCrypto, error) {
+	return service.trendRepo.FetchForDay(day)
+}
+
+// CountTrendingDays counts the days a symbol was trending between two days, both included.
+func (service *Impl) CountTrendingDays(symbol string, from string, to string) int {
+	count, err := service.trendRepo.CountForSymbolBetween(symbol, from, to)
+	if err != nil {
+		return 0
+	}
+	return int(count)
+}
+
+func (service *Impl) FetchTopForDay(day string, maxRank int) ([]entities.Historical, error) {
+	return service.histoRepo.FetchTopForDay(day, maxRank)
+}
+
 func (service *Impl) FetchForSymbolYesterday(symbol string) (entities.Historical, error) {
 	yesterday := time.Now().AddDate(0, 0, -1).Format(dates.DateFormat)
 
-	return service.histoRepo.FetchForSymbolForDay(symbol, yesterday)
+	return service.FetchForSymbolForDay(symbol, yesterday)
 }
 
 func (service *Impl) FetchForSymbolForTwoDaysAgo(symbol string) (entities.Historical, error) {
 	twoDays := time.Now().AddDate(0, 0, -2).Format(dates.DateFormat)
 
-	return service.histoRepo.FetchForSymbolForDay(symbol, twoDays)
+	return service.FetchForSymbolForDay(symbol, twoDays)
 }
 
 func (service *Impl) FetchForSymbol7DaysAgo(symbol string) (entities.Historical, error) {
 	sevenDaysAgo := time.Now().AddDate(0, 0, -8).Format(dates.DateFormat)
 
-	return service.histoRepo.FetchForSymbolForDay(symbol, sevenDaysAgo)
+	return service.FetchForSymbolForDay(symbol, sevenDaysAgo)
+}
+
+// FetchPriceChange24h returns the latest known price of a symbol and its price 24 hours before.
+// The live quote is compared with the one stored a day earlier, or with the 24h change CMC sent along,
+// the two last daily snapshots otherwise; ErrNoPriceReference is returned when none is known.
+func (service *Impl) FetchPriceChange24h(symbol string) (float64, float64, error) {
+	live, errLive := service.FetchLiveQuote(symbol)
+	if errLive == nil {
+		reference := live.Timestamp.Add(-24 * time.Hour)
+		dayAgo, errDayAgo := service.quotesRepo.FetchLatestForSymbolBefore(symbol, reference)
+		if errDayAgo == nil && dayAgo.Price > 0 && reference.Sub(dayAgo.Timestamp) <= quoteMatchTolerance {
+			return live.Price, dayAgo.Price, nil
+		}
+		if live.PercentChange24h > -100 {
+			return live.Price, live.Price / (1 + live.PercentChange24h/100), nil
+		}
+		return 0, 0, ErrNoPriceReference
+	}
+
+	yesterday, err := service.FetchForSymbolYesterday(symbol)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	twoDaysAgo, err := service.FetchForSymbolForTwoDaysAgo(symbol)
+	if err != nil {
+		return 0, 0, err
+	}
+	if twoDaysAgo.Price <= 0 {
+		return 0, 0, ErrNoPriceReference
+	}
+
+	return yesterday.Price, twoDaysAgo.Price, nil
 }
 
 func (service *Impl) IsCryptoTrendyToday(symbol string) bool {
@@ -336,57 +799,13 @@ func (service *Impl) IsCryptoTrendyToday(symbol string) bool {
 func (service *Impl) FetchCommunityDataForSymbolYesterday(id int) (entities.CommunityData, error) {
 	yesterday := time.Now().AddDate(0, 0, -1).Format(dates.DateFormat)
 
-	return service.communityRepo.FetchForSymbolYesterday(id, yesterday)
+	return service.communityRepo.FetchForDay(id, yesterday)
 }
 
-func (service *Impl) GetTopGainers() ([]Gainer, error) {
-
-	twoDays := time.Now().AddDate(0, 0, -2).Format(dates.DateFormat)
-	yesterday := time.Now().AddDate(0, 0, -1).Format(dates.DateFormat)
-	yesterdayData, err := service.histoRepo.FetchForDay(yesterday)
-	twoDaysData, err2 := service.histoRepo.FetchForDay(twoDays)
-	if err != nil {
-		log.Error().Err(err).Msg("failed to fetch yesterdayData")
-		return nil, err
-	}
-	if err2 != nil {
-		log.Error().Err(err2).Msg("failed to fetch twoDaysData")
-		return nil, err2
-	}
-	twoDaysMap := make(map[string]float64)
-	for _, data := range twoDaysData {
-		twoDaysMap[data.Symbol] = data.Price
-	}
-
-	// Compute percentage change
-	var gainers []Gainer
-	for _, yData := range yesterdayData {
-		oldPrice, exists := twoDaysMap[yData.Symbol]
-		if !exists || oldPrice == 0 {
-			continue // Skip if no data or invalid price
-		}
-
-		priceChange := yData.Price - oldPrice
-		percentChange := (priceChange / oldPrice) * 100
-
-		// Consider only positive changes (gainers)
-		if percentChange > 0 {
-			gainers = append(gainers, Gainer{
-				Symbol:        yData.Symbol,
-				PriceChange:   priceChange,
-				PercentChange: percentChange,
-			})
-		}
-	}
-
-	// Sort gainers by highest percentage increase
-	sort.Slice(gainers, func(i, j int) bool {
-		return gainers[i].PercentChange > gainers[j].PercentChange
-	})
+func (service *Impl) FetchCommunityDataForDay(id int, day string) (entities.CommunityData, error) {
+	return service.communityRepo.FetchForDay(id, day)
+}
 
-	// Return top 3 gainers
-	if len(gainers) > 3 {
-		return gainers[:3], nil
-	}
-	return gainers, nil
+func (service *Impl) FetchCommunityDataBetween(id int, from string, to string) ([]entities.CommunityData, error) {
+	return service.communityRepo.FetchBetween(id, from, to)
 }
@@ -0,0 +1,286 @@
+package coinmarketcap_test
+
+import (
+	"crypto-analytics/application/apptest"
+	"crypto-analytics/models/constants"
+	"crypto-analytics/models/entities"
+	backfillRepo "crypto-analytics/repositories/backfill"
+	communityRepo "crypto-analytics/repositories/community"
+	historicalRepo "crypto-analytics/repositories/historical"
+	failuresRepo "crypto-analytics/repositories/historicalfailures"
+	cmcService "crypto-analytics/services/coinmarketcap"
+	"crypto-analytics/utils/dates"
+	"crypto-analytics/utils/insights"
+	"errors"
+	"math"
+	"slices"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+const testDay = "2025-03-01"
+
+func TestFetchAndSaveHistoricalPage(t *testing.T) {
+	env := apptest.New(t, nil)
+	service := env.CoinMarketCap()
+
+	if err := cmcService.FetchAndSaveHistoricalPage(service, testDay, 1); err != nil {
+		t.Fatalf("cannot fetch historical page: %v", err)
+	}
+
+	historicals, err := historicalRepo.New(env.DB).FetchForDay(testDay)
+	if err != nil {
+		t.Fatalf("cannot fetch historicals: %v", err)
+	}
+	if len(historicals) != 7 {
+		t.Errorf("saved %d historicals, want 7", len(historicals))
+	}
+
+	rlc, err := service.FetchForSymbolForDay("RLC", testDay)
+	if err != nil {
+		t.Fatalf("RLC not saved: %v", err)
+	}
+	if rlc.ID != 1637 || rlc.Rank != 240 || rlc.Price != 1.05 {
+		t.Errorf("RLC = {ID: %d, Rank: %d, Price: %v}, want {ID: 1637, Rank: 240, Price: 1.05}", rlc.ID, rlc.Rank, rlc.Price)
+	}
+
+	if failures, _ := failuresRepo.New(env.DB).FetchAll(); len(failures) != 0 {
+		t.Errorf("recorded %d failures, want none", len(failures))
+	}
+}
+
+func TestFetchAndSaveHistoricalPageSavesTags(t *testing.T) {
+	service := apptest.New(t, nil).CoinMarketCap()
+
+	if err := cmcService.FetchAndSaveHistoricalPage(service, testDay, 1); err != nil {
+		t.Fatalf("cannot fetch historical page: %v", err)
+	}
+
+	tags, err := service.FetchTokenTags(testDay)
+	if err != nil {
+		t.Fatalf("cannot fetch token tags: %v", err)
+	}
+	for _, tag := range []string{"ai-big-data", "distributed-computing", "depin"} {
+		if !slices.Contains(tags["iexec-rlc"], tag) {
+			t.Errorf("RLC tags %v, missing %s", tags["iexec-rlc"], tag)
+		}
+	}
+}
+
+func TestFetchAndSaveHistoricalPageRecordsFailure(t *testing.T) {
+	env := apptest.New(t, nil)
+	viper.Set(constants.CoinMarketCapBaseURL, env.Server.URL+"/unknown")
+	service := env.CoinMarketCap()
+
+	if err := cmcService.FetchAndSaveHistoricalPage(service, testDay, 201); err == nil {
+		t.Fatal("expected an error from an unknown endpoint")
+	}
+
+	failure, err := failuresRepo.New(env.DB).Find(testDay, 201)
+	if err != nil {
+		t.Fatalf("failure not recorded: %v", err)
+	}
+	if failure.Attempts != 1 {
+		t.Errorf("attempts = %d, want 1", failure.Attempts)
+	}
+}
+
+func TestIsHistoricalRetryDue(t *testing.T) {
+	now := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		attempts int
+		ago      time.Duration
+		due      bool
+	}{
+		{1, 44 * time.Minute, false},
+		{1, 45 * time.Minute, true},
+		{3, 2 * time.Hour, false},
+		{3, 3 * time.Hour, true},
+		{7, 47 * time.Hour, false},
+		{7, 48 * time.Hour, true},
+		{8, 30 * 24 * time.Hour, false},
+	}
+	for _, test := range tests {
+		failure := entities.HistoricalFailure{Attempts: test.attempts, LastAttempt: now.Add(-test.ago)}
+		if due := cmcService.IsHistoricalRetryDue(failure, now); due != test.due {
+			t.Errorf("retry after %d attempts, the last %v ago: due = %t, want %t", test.attempts, test.ago, due, test.due)
+		}
+	}
+}
+
+func TestFetchForSymbolBetweenKeepsOneToken(t *testing.T) {
+	env := apptest.New(t, nil)
+	service := env.CoinMarketCap()
+	repo := historicalRepo.New(env.DB)
+	for _, historical := range []entities.Historical{
+		{ID: 1455, Slug: "golem-network-tokens", Symbol: "GLM", Day: "2025-03-01", Rank: 120, Price: 0.3},
+		{ID: 1455, Slug: "golem-network-tokens", Symbol: "GLM", Day: "2025-03-02", Rank: 121, Price: 0.31},
+		{ID: 99999, Slug: "glm-copycat", Symbol: "GLM", Day: "2025-03-01", Rank: 90, Price: 12},
+		{ID: 99999, Slug: "glm-copycat", Symbol: "GLM", Day: "2025-03-02", Rank: 2400, Price: 13},
+	} {
+		if err := repo.Save(historical); err != nil {
+			t.Fatalf("cannot save historical: %v", err)
+		}
+	}
+
+	// GLM is not watched, the token is the best ranked one on the last day, even when another one ranked better before.
+	historicals, err := service.FetchForSymbolBetween("GLM", "2025-03-01", "2025-03-02")
+	if err != nil {
+		t.Fatalf("cannot fetch GLM history: %v", err)
+	}
+	if len(historicals) != 2 {
+		t.Fatalf("GLM history has %d days, want 2", len(historicals))
+	}
+	for _, historical := range historicals {
+		if historical.ID != 1455 {
+			t.Errorf("GLM history mixes %s on %s", historical.Slug, historical.Day)
+		}
+	}
+}
+
+func TestFetchAndSaveQuotes(t *testing.T) {
+	service := apptest.New(t, nil).CoinMarketCap()
+
+	if err := cmcService.FetchAndSaveQuotes(service); err != nil {
+		t.Fatalf("cannot fetch quotes: %v", err)
+	}
+
+	quote, err := service.FetchLiveQuote("RLC")
+	if err != nil {
+		t.Fatalf("no live quote for RLC: %v", err)
+	}
+	if quote.CryptoID != 1637 || quote.Price != 1.0605 || quote.PercentChange24h != 1.0 {
+		t.Errorf("RLC quote = {CryptoID: %d, Price: %v, PercentChange24h: %v}, want {1637, 1.0605, 1}", quote.CryptoID, quote.Price, quote.PercentChange24h)
+	}
+
+	// A single quote has no stored reference a day earlier, the 24h change sent by CMC is used instead.
+	price, previous, err := service.FetchPriceChange24h("RLC")
+	if err != nil {
+		t.Fatalf("no 24h price change for RLC: %v", err)
+	}
+	if price != 1.0605 || math.Abs(previous-1.05) > 1e-9 {
+		t.Errorf("24h change = (%v, %v), want (1.0605, 1.05)", price, previous)
+	}
+}
+
+func TestFetchAndSaveQuotesOutsideTheListing(t *testing.T) {
+	env := apptest.New(t, fstest.MapFS{
+		"listing.json":          {Data: []byte(`{"data":{"cryptoCurrencyList":[]}}`)},
+		"detail_lite_1637.json": {Data: []byte(`{"data":{"id":1637,"statistics":{"price":1.0605,"priceChangePercentage24h":1.0,"marketCap":76760000}}}`)},
+		"detail_lite_7431.json": {Data: []byte(`{"data":{"id":7431,"statistics":{"price":1.3635,"priceChangePercentage24h":-2.5,"marketCap":343400000}}}`)},
+	})
+	service := env.CoinMarketCap()
+	if err := historicalRepo.New(env.DB).Save(entities.Historical{ID: 7431, Slug: "akash-network", Symbol: "AKT", Day: testDay, Rank: 150}); err != nil {
+		t.Fatalf("cannot save historical: %v", err)
+	}
+	service.AddQuotedSymbols(func() ([]string, error) { return []string{"AKT", "RLC", "NOPE"}, nil })
+
+	// PHA and SCRT are watched but have no detail, their failure does not prevent saving the others.
+	if err := cmcService.FetchAndSaveQuotes(service); err == nil {
+		t.Error("expected an error for the watched tokens without detail")
+	}
+
+	for _, want := range []entities.Quote{
+		{CryptoID: 1637, Symbol: "RLC", Price: 1.0605, PercentChange24h: 1.0},
+		{CryptoID: 7431, Symbol: "AKT", Price: 1.3635, PercentChange24h: -2.5},
+	} {
+		quote, err := service.FetchLiveQuote(want.Symbol)
+		if err != nil {
+			t.Fatalf("no live quote for %s: %v", want.Symbol, err)
+		}
+		if quote.CryptoID != want.CryptoID || quote.Price != want.Price || quote.PercentChange24h != want.PercentChange24h {
+			t.Errorf("%s quote = {CryptoID: %d, Price: %v, PercentChange24h: %v}, want %+v", want.Symbol, quote.CryptoID, quote.Price, quote.PercentChange24h, want)
+		}
+	}
+	if _, err := service.FetchLiveQuote("PHA"); err == nil {
+		t.Error("PHA quoted without detail")
+	}
+}
+
+func TestFetchAndSaveCommunityData(t *testing.T) {
+	env := apptest.New(t, nil)
+	service := env.CoinMarketCap()
+	if err := cmcService.FetchAndSaveHistoricalPage(service, testDay, 1); err != nil {
+		t.Fatalf("cannot fetch historical page: %v", err)
+	}
+
+	if err := cmcService.FetchAndSaveCommunityData(service, false); err != nil {
+		t.Fatalf("cannot fetch community data: %v", err)
+	}
+
+	// The watchlist and the peers outside of it, GLM and AKT.
+	if count := communityRepo.New(env.DB).Count(); count != 5 {
+		t.Errorf("saved %d community data, want 5", count)
+	}
+
+	rlc, err := service.FetchCommunityDataForDay(1637, time.Now().Format(dates.DateFormat))
+	if err != nil {
+		t.Fatalf("RLC community data not saved: %v", err)
+	}
+	if rlc.WatchCount != 98234 || rlc.Followers != 15234 {
+		t.Errorf("RLC community = {WatchCount: %d, Followers: %d}, want {98234, 15234}", rlc.WatchCount, rlc.Followers)
+	}
+}
+
+func TestBackfillLease(t *testing.T) {
+	tests := []struct {
+		name      string
+		updatedAt time.Duration
+		force     bool
+		err       error
+	}{
+		{"running in another process", time.Minute, false, cmcService.ErrBackfillRunning},
+		{"interrupted", time.Hour, false, nil},
+		{"forced", time.Minute, true, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			env := apptest.New(t, nil)
+			service := env.CoinMarketCap()
+			if err := backfillRepo.New(env.DB).Save(entities.BackfillProgress{Running: true}); err != nil {
+				t.Fatalf("cannot save backfill progress: %v", err)
+			}
+			// Saving sets the update time to now.
+			updatedAt := time.Now().Add(-test.updatedAt)
+			if err := env.DB.GetDB().Model(&entities.BackfillProgress{}).Where("1 = 1").UpdateColumn("updated_at", updatedAt).Error; err != nil {
+				t.Fatalf("cannot backdate backfill progress: %v", err)
+			}
+
+			progress, err := service.Backfill(testDay, testDay, test.force)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("error = %v, want %v", err, test.err)
+			}
+			if test.err == nil && (progress.Running || progress.DonePages != progress.PlannedPages) {
+				t.Errorf("progress = %+v, want every page done", progress)
+			}
+		})
+	}
+}
+
+func TestCheckTrending(t *testing.T) {
+	env := apptest.New(t, nil)
+	service := env.CoinMarketCap()
+
+	if _, err := cmcService.CheckTrending(service); !errors.Is(err, insights.ErrNeverSucceeded) {
+		t.Errorf("error before any fetch = %v, want %v", err, insights.ErrNeverSucceeded)
+	}
+
+	if err := service.FetchAndSaveTrendingCrypto(); err != nil {
+		t.Fatalf("cannot fetch trending crypto: %v", err)
+	}
+	if _, err := cmcService.CheckTrending(service); err != nil {
+		t.Errorf("error after a fetch = %v", err)
+	}
+
+	// As after a restart long after the last fetch.
+	stale := time.Now().Add(-viper.GetDuration(constants.HealthTrendingMaxAge) - time.Minute)
+	if err := env.DB.GetDB().Model(&entities.TrendingCrypto{}).Where("1 = 1").UpdateColumn("updated_at", stale).Error; err != nil {
+		t.Fatalf("cannot backdate trending crypto: %v", err)
+	}
+	if _, err := cmcService.CheckTrending(service); err == nil {
+		t.Error("no error once the trending crypto are stale")
+	}
+}
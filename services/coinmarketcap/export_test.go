@@ -0,0 +1,12 @@
+package coinmarketcap
+
+// The jobs of the service, exported to the external tests.
+var (
+	FetchAndSaveHistoricalPage = (*Impl).fetchAndSaveHistoricalPage
+	FetchAndSaveQuotes         = (*Impl).fetchAndSaveQuotes
+	FetchAndSaveCommunityData  = (*Impl).fetchAndSaveCommunityData
+)
+
+var IsHistoricalRetryDue = isHistoricalRetryDue
+
+var CheckTrending = (*Impl).checkTrending
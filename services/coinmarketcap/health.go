@@ -0,0 +1,51 @@
+package coinmarketcap
+
+import (
+	"crypto-analytics/models/constants"
+	"crypto-analytics/utils/dates"
+	"crypto-analytics/utils/insights"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+)
+
+func (service *Impl) RegisterHealthChecks(probes insights.Probes) {
+	probes.AddCheck("cmc-historical", true, service.checkHistorical)
+	probes.AddCheck("cmc-trending", true, service.checkTrending)
+	probes.AddCheck("cmc-quotes", false, service.quotesBeat.Check(quoteFreshness))
+}
+
+// checkHistorical expects yesterday's listing to be stored, once the nightly fetch had time to run.
+func (service *Impl) checkHistorical() (time.Time, error) {
+	expected := time.Now().Add(-historicalHealthGrace).AddDate(0, 0, -1).Format(dates.DateFormat)
+	latest, err := service.histoRepo.FetchLatestDay()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	lastSuccess, _ := time.ParseInLocation(dates.DateFormat, latest, time.Local)
+	if latest < expected {
+		return lastSuccess, fmt.Errorf("no historical data since %s, expected %s", latest, expected)
+	}
+	return lastSuccess, nil
+}
+
+// checkTrending expects the trending listing to be stored recently; the rows tell it across restarts, unlike a heartbeat.
+func (service *Impl) checkTrending() (time.Time, error) {
+	latest, err := service.trendRepo.FetchLatest()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return time.Time{}, insights.ErrNeverSucceeded
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	maxAge := viper.GetDuration(constants.HealthTrendingMaxAge)
+	if age := time.Since(latest.UpdatedAt); age > maxAge {
+		return latest.UpdatedAt, fmt.Errorf("no trending crypto stored for %s, more than %s", age.Round(time.Second), maxAge)
+	}
+	return latest.UpdatedAt, nil
+}
@@ -0,0 +1,92 @@
+package coinmarketcap
+
+import (
+	"crypto-analytics/models/entities"
+	"crypto-analytics/utils/dates"
+	"sort"
+	"time"
+)
+
+// GetMovers compares the prices of a day with the ones days before and returns
+// the limit biggest gainers and losers passing the movers filter.
+func (service *Impl) GetMovers(day string, days int, limit int) (Movers, error) {
+	current, err := time.ParseInLocation(dates.DateFormat, day, time.Local)
+	if err != nil {
+		return Movers{}, err
+	}
+	since := current.AddDate(0, 0, -days).Format(dates.DateFormat)
+
+	listing, err := service.histoRepo.FetchForDay(day)
+	if err != nil {
+		return Movers{}, err
+	}
+	previousListing, err := service.histoRepo.FetchForDay(since)
+	if err != nil {
+		return Movers{}, err
+	}
+	if len(listing) == 0 || len(previousListing) == 0 {
+		return Movers{}, ErrNoListing
+	}
+
+	previousPrices := make(map[int]float64, len(previousListing))
+	for _, historical := range dedupeByCryptoID(previousListing) {
+		previousPrices[historical.ID] = historical.Price
+	}
+
+	movers := Movers{Day: day, Since: since}
+	for _, historical := range dedupeByCryptoID(listing) {
+		previousPrice, found := previousPrices[historical.ID]
+		if !found || previousPrice <= 0 || !service.moversFilter.accepts(historical) {
+			continue
+		}
+
+		mover := Mover{
+			CryptoID:      historical.ID,
+			Symbol:        historical.Symbol,
+			Name:          historical.Name,
+			Rank:          historical.Rank,
+			Marketcap:     historical.Marketcap,
+			Price:         historical.Price,
+			PreviousPrice: previousPrice,
+			PercentChange: (historical.Price - previousPrice) / previousPrice * 100,
+		}
+		if mover.PercentChange > 0 {
+			movers.Gainers = append(movers.Gainers, mover)
+		} else if mover.PercentChange < 0 {
+			movers.Losers = append(movers.Losers, mover)
+		}
+	}
+
+	sort.Slice(movers.Gainers, func(i, j int) bool { return movers.Gainers[i].PercentChange > movers.Gainers[j].PercentChange })
+	sort.Slice(movers.Losers, func(i, j int) bool { return movers.Losers[i].PercentChange < movers.Losers[j].PercentChange })
+	if limit > 0 {
+		movers.Gainers = movers.Gainers[:min(limit, len(movers.Gainers))]
+		movers.Losers = movers.Losers[:min(limit, len(movers.Losers))]
+	}
+
+	return movers, nil
+}
+
+func (filter MoversFilter) accepts(historical entities.Historical) bool {
+	if filter.MaxRank > 0 && (historical.Rank <= 0 || historical.Rank > filter.MaxRank) {
+		return false
+	}
+	return historical.Marketcap >= filter.MinMarketcap
+}
+
+// dedupeByCryptoID keeps the best ranked row of each CMC ID, a token being
+// stored twice on the same day when its slug changes.
+func dedupeByCryptoID(historicals []entities.Historical) []entities.Historical {
+	best := make(map[int]entities.Historical, len(historicals))
+	for _, historical := range historicals {
+		if existing, found := best[historical.ID]; !found || (historical.Rank > 0 && (existing.Rank == 0 || historical.Rank < existing.Rank)) {
+			best[historical.ID] = historical
+		}
+	}
+
+	result := make([]entities.Historical, 0, len(best))
+	for _, historical := range best {
+		result = append(result, historical)
+	}
+	return result
+}
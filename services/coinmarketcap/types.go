@@ -3,21 +3,53 @@ package coinmarketcap
 import (
 	"crypto-analytics/models/entities"
 	"crypto-analytics/pkg/observer"
+	backfillRepo "crypto-analytics/repositories/backfill"
 	communityRepo "crypto-analytics/repositories/community"
 	historicalRepo "crypto-analytics/repositories/historical"
+	failuresRepo "crypto-analytics/repositories/historicalfailures"
+	quotesRepo "crypto-analytics/repositories/quotes"
+	tokenTagsRepo "crypto-analytics/repositories/tokentags"
 	trendingRepo "crypto-analytics/repositories/trending"
+	"crypto-analytics/services/sectors"
+	"crypto-analytics/services/watchlist"
+	"crypto-analytics/utils/insights"
+	"errors"
 	"net/http"
-	"strings"
+	"sync"
 	"time"
 )
 
 const (
-	cmcBaseAPI        = "https://api.coinmarketcap.com"
-	convertIDs        = "2781,1"
-	halvingDate       = "2024-04-19"
-	delayBetweenCall  = 2 * time.Second
-	clientHTTPTimeout = 15 * time.Second
-	limitDataPerCall  = 200
+	// Day of the last Bitcoin halving, start of the stored history.
+	HalvingDate = "2024-04-19"
+
+	convertIDs       = "2781,1"
+	limitDataPerCall = 200
+	// Listing pages fetched per day, i.e. the top 1000.
+	historicalPagesPerDay = 5
+	// A stored page with fewer rows is considered incomplete and fetched again.
+	minRowsPerCompletePage = limitDataPerCall / 2
+	// Delay before retrying a failed page, doubled on each attempt; below the hourly retry job to retry on its next run.
+	historicalRetryBackoff = 45 * time.Minute
+	// Attempts after which the retry job gives up on a page.
+	maxHistoricalAttempts = 8
+	// A backfill marked as running without progress for longer was interrupted, another process may take it over.
+	backfillLeaseTimeout = 15 * time.Minute
+	limitQuotesCall      = 1000
+	usdQuoteName         = "USD"
+	// A quote older than this is not considered live anymore.
+	quoteFreshness = 1 * time.Hour
+	// Yesterday's listing is fetched in the night; until then the day before is accepted.
+	historicalHealthGrace = 6 * time.Hour
+	// Maximum gap accepted between the quote found and the 24h reference point.
+	quoteMatchTolerance = 2 * time.Hour
+)
+
+var (
+	ErrNoLiveQuote      = errors.New("no live quote for this symbol")
+	ErrNoPriceReference = errors.New("no price known 24 hours ago for this symbol")
+	ErrBackfillRunning  = errors.New("historical backfill already running")
+	ErrNoListing        = errors.New("no stored listing for this day")
 )
 
 type ProfileResponse struct {
@@ -52,10 +84,22 @@ type LiteResponse struct {
 }
 
 type LiteData struct {
-	ID         int    `json:"id"`
-	WatchCount string `json:"watchCount"`
+	ID         int            `json:"id"`
+	Symbol     string         `json:"symbol"`
+	WatchCount string         `json:"watchCount"`
+	Statistics LiteStatistics `json:"statistics"`
+}
+
+// LiteStatistics is the USD quote of a token, read when the token is outside the listing.
+type LiteStatistics struct {
+	Price                    float64 `json:"price"`
+	PriceChangePercentage24h float64 `json:"priceChangePercentage24h"`
+	MarketCap                float64 `json:"marketCap"`
 }
 
+// SymbolsSource lists symbols followed outside the watchlist, e.g. by the subscribers.
+type SymbolsSource func() ([]string, error)
+
 type HistoricalResponse struct {
 	CryptoCurrencies []CryptoCurrency `json:"data"`
 }
@@ -68,8 +112,11 @@ type Data struct {
 }
 
 type Quotes struct {
-	Price    float64 `json:"price"`
-	MaketCap float64 `json:"marketCap"`
+	Name             string  `json:"name"`
+	Price            float64 `json:"price"`
+	MaketCap         float64 `json:"marketCap"`
+	Volume24h        float64 `json:"volume24h"`
+	PercentChange24h float64 `json:"percentChange24h"`
 }
 
 type CryptoCurrency struct {
@@ -83,42 +130,70 @@ type CryptoCurrency struct {
 	Tags        []string  `json:"tags"`
 }
 
-type Gainer struct {
+// Mover is the price change of a token over a window, identified by its CMC ID.
+type Mover struct {
+	CryptoID      int
 	Symbol        string
-	PriceChange   float64
+	Name          string
+	Rank          int
+	Marketcap     float64
+	Price         float64
+	PreviousPrice float64
 	PercentChange float64
 }
 
-func (c *CryptoCurrency) KeepOnlyRelevantsTags() string {
-	tags := ""
-	if len(c.Tags) == 0 {
-		return tags
-	}
+// MoversFilter leaves illiquid tokens out of the movers; zero values disable a criterion.
+type MoversFilter struct {
+	MinMarketcap float64
+	MaxRank      int
+}
+
+type Movers struct {
+	Day     string
+	Since   string
+	Gainers []Mover
+	Losers  []Mover
+}
 
-	for _, t := range c.Tags {
-		search := strings.ToLower(t)
-		if strings.Contains(search, "ai-") || strings.Contains(search, "-ai") || strings.Contains(search, "depin") || strings.Contains(search, "distributed-computing") {
-			if len(tags) > 0 {
-				tags += ";"
-			}
-			tags += search
+// USDQuote returns the quote expressed in USD, or the first one when quotes are not named.
+func (c *CryptoCurrency) USDQuote() (Quotes, bool) {
+	for _, quote := range c.Quotes {
+		if quote.Name == usdQuoteName {
+			return quote, true
 		}
 	}
-
-	return tags
-
+	if len(c.Quotes) > 0 {
+		return c.Quotes[0], true
+	}
+	return Quotes{}, false
 }
 
 type Service interface {
 	IsCryptoTrendyToday(symbol string) bool
 	IsCryptoTrendyYersterday(symbol string) bool
+	IsCryptoTrendyAtDay(symbol string, day string) bool
+	FetchForSymbolForDay(symbol string, day string) (entities.Historical, error)
+	FetchForIDForDay(id int, day string) (entities.Historical, error)
+	FetchForSymbolBetween(symbol string, from string, to string) ([]entities.Historical, error)
+	FetchTopForDay(day string, maxRank int) ([]entities.Historical, error)
+	FetchTokenTags(day string) (map[string][]string, error)
+	FetchTrendingForDay(day string) ([]entities.TrendingCrypto, error)
+	CountTrendingDays(symbol string, from string, to string) int
 	FetchForSymbolYesterday(symbol string) (entities.Historical, error)
 	FetchForSymbol7DaysAgo(symbol string) (entities.Historical, error)
 	FetchForSymbolForTwoDaysAgo(symbol string) (entities.Historical, error)
 	FetchCommunityDataForSymbolYesterday(id int) (entities.CommunityData, error)
-	FetchAndSaveTrendingCrypto()
-	GetTopGainers() ([]Gainer, error)
+	FetchCommunityDataForDay(id int, day string) (entities.CommunityData, error)
+	FetchCommunityDataBetween(id int, from string, to string) ([]entities.CommunityData, error)
+	FetchPriceChange24h(symbol string) (float64, float64, error)
+	FetchLiveQuote(symbol string) (entities.Quote, error)
+	GetBackfillProgress() entities.BackfillProgress
+	Backfill(from string, to string, force bool) (entities.BackfillProgress, error)
+	FetchAndSaveTrendingCrypto() error
+	GetMovers(day string, days int, limit int) (Movers, error)
 	RegisterObserver(o observer.Observer)
+	AddQuotedSymbols(source SymbolsSource)
+	RegisterHealthChecks(probes insights.Probes)
 }
 
 type Impl struct {
@@ -127,5 +202,17 @@ type Impl struct {
 	trendRepo     trendingRepo.Repository
 	histoRepo     historicalRepo.Repository
 	communityRepo communityRepo.Repository
-	observers     map[observer.Observer]struct{}
+	quotesRepo    quotesRepo.Repository
+	failuresRepo  failuresRepo.Repository
+	backfillRepo  backfillRepo.Repository
+	tokenTagsRepo tokenTagsRepo.Repository
+	backfillMutex sync.Mutex
+	moversFilter  MoversFilter
+	quotesBeat    insights.Heartbeat
+	watchlist     watchlist.Service
+	sectors       sectors.Service
+	quotedSymbols []SymbolsSource
+	// Guards the observers, notified from the jobs while the application registers them.
+	observersMutex sync.RWMutex
+	observers      map[observer.Observer]struct{}
 }
@@ -1,8 +1,14 @@
 package cryptorank
 
 import (
+	"crypto-analytics/models/constants"
+	"crypto-analytics/models/entities"
 	"crypto-analytics/pkg/observer"
+	marketIndicatorsRepo "crypto-analytics/repositories/marketindicators"
+	"crypto-analytics/utils/dates"
+	"crypto-analytics/utils/insights"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -10,20 +16,20 @@ import (
 	"github.com/go-co-op/gocron/v2"
 	"github.com/patrickmn/go-cache"
 	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
 )
 
-func New(scheduler gocron.Scheduler) (*Impl, error) {
+func New(scheduler gocron.Scheduler, client *http.Client, repo marketIndicatorsRepo.Repository) (*Impl, error) {
 	service := &Impl{
-		baseURL: cryptorankBaseAPI,
-		client: &http.Client{
-			Timeout: clientHTTPTimeout,
-		},
-		cache: cache.New(20*time.Minute, 1*time.Hour),
+		baseURL: viper.GetString(constants.CryptoRankBaseURL),
+		client:  client,
+		cache:   cache.New(20*time.Minute, 1*time.Hour),
+		repo:    repo,
 	}
 
 	_, errJob := scheduler.NewJob(
 		gocron.CronJob("*/15 * * * *", true),
-		gocron.NewTask(func() { service.fetchAndCache() }),
+		gocron.NewTask(func() error { return service.fetchAndCache() }),
 		gocron.WithName("Fetch market indicator"),
 	)
 	if errJob != nil {
@@ -45,6 +51,13 @@ func (service *Impl) notify(e observer.Event) {
 	}
 }
 
+func (service *Impl) RegisterHealthChecks(probes insights.Probes) {
+	probes.AddCheck("cryptorank-cache", true, func() (time.Time, error) {
+		_, err := service.GetMarketIndicator()
+		return service.cacheBeat.Last(), err
+	})
+}
+
 func (service *Impl) GetMarketIndicator() (MarketIndicator, error) {
 
 	var marketIndicator MarketIndicator
@@ -56,7 +69,7 @@ func (service *Impl) GetMarketIndicator() (MarketIndicator, error) {
 	return marketIndicator, nil
 }
 
-func (service *Impl) fetchAndCache() {
+func (service *Impl) fetchAndCache() error {
 
 	index, err := service.fetchFearAndGreed()
 	global, errD := service.fetchGlobalIndicator()
@@ -66,22 +79,76 @@ func (service *Impl) fetchAndCache() {
 		indicator := MarketIndicator{
 			FearGreedIndex:          index.Today,
 			FearGreedYesterdayIndex: index.Yesterday,
+			FearGreedLastWeekIndex:  index.LastWeek,
+			FearGreedLastMonthIndex: index.LastMonth,
 			BtcDominance:            global.BtcDominance,
+			EthDominance:            global.EthDominance,
 			TotalMarketCap:          global.TotalMarketCap,
+			TotalVolume24H:          global.TotalVolume24H,
 		}
 		service.cache.SetDefault(marketIndicatorCacheKey, indicator)
+		service.cacheBeat.Beat()
+		service.saveIndicator(index, global)
 		service.notify(observer.Event{E: observer.MarketIndicatorEvent})
-	} else {
-		log.Error().Err(err).Msg("market indicator")
-		log.Error().Err(errD).Msg("market indicator")
+		return nil
+	}
+
+	log.Error().Err(err).Msg("market indicator")
+	log.Error().Err(errD).Msg("market indicator")
+	if err == nil && errD == nil {
+		return ErrIncompleteMarketIndicator
+	}
+	return errors.Join(err, errD)
+}
+
+func (service *Impl) saveIndicator(index *FearGreedIndex, global *GlobalIndicator) {
+	now := time.Now()
+	err := service.repo.Save(entities.MarketIndicator{
+		Timestamp:                   now.UTC(),
+		Day:                         now.Format(dates.DateFormat),
+		FearGreedIndex:              index.Today,
+		FearGreedYesterday:          index.Yesterday,
+		FearGreedLastWeek:           index.LastWeek,
+		FearGreedLastMonth:          index.LastMonth,
+		BtcDominance:                global.BtcDominance,
+		BtcDominanceChangePercent:   global.BtcDominanceChangePercent,
+		EthDominance:                global.EthDominance,
+		EthDominanceChangePercent:   global.EthDominanceChangePercent,
+		TotalMarketCap:              global.TotalMarketCap,
+		TotalMarketCapChangePercent: global.TotalMarketCapChangePercent,
+		TotalVolume24h:              global.TotalVolume24H,
+		TotalVolume24hChangePercent: global.TotalVolume24HChangePercent,
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Cannot save market indicator")
+	}
+}
+
+func (service *Impl) GetMarketIndicatorHistory(from time.Time, to time.Time) ([]entities.MarketIndicator, error) {
+	return service.repo.FetchBetween(from, to)
+}
+
+func (service *Impl) GetDailyMarketIndicators(from string, to string) ([]entities.MarketIndicator, error) {
+	return service.repo.FetchDailyBetween(from, to)
+}
+
+func (service *Impl) GetMarketTrend(window time.Duration) (MarketTrend, error) {
+	current, err := service.repo.FetchLatest()
+	if err != nil {
+		return MarketTrend{}, err
+	}
+	previous, err := service.repo.FetchLatestBefore(current.Timestamp.Add(-window))
+	if err != nil {
+		return MarketTrend{}, err
 	}
+	return MarketTrend{Current: current, Previous: previous}, nil
 }
 
 func (service *Impl) fetchFearAndGreed() (*FearGreedIndex, error) {
 	log.Info().Msg("Start fetching fear and gred index")
 
 	endpoint := fmt.Sprintf("%s/v0/widgets/fear-and-greed-index", service.baseURL)
-	resp, err := http.Get(endpoint)
+	resp, err := service.client.Get(endpoint)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch data: %w", err)
 	}
@@ -103,7 +170,7 @@ func (service *Impl) fetchGlobalIndicator() (*GlobalIndicator, error) {
 	log.Info().Msg("Start fetching global indicator")
 
 	endpoint := fmt.Sprintf("%s/v0/global", service.baseURL)
-	resp, err := http.Get(endpoint)
+	resp, err := service.client.Get(endpoint)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch data: %w", err)
 	}
@@ -0,0 +1,57 @@
+package cryptorank_test
+
+import (
+	"crypto-analytics/application/apptest"
+	marketIndicatorsRepo "crypto-analytics/repositories/marketindicators"
+	"crypto-analytics/services/cryptorank"
+	"testing"
+	"time"
+
+	"github.com/go-co-op/gocron/v2"
+)
+
+func TestNewFetchesMarketIndicator(t *testing.T) {
+	env := apptest.New(t, nil)
+
+	// The scheduler is never started, only the fetch done at creation runs.
+	scheduler, err := gocron.NewScheduler()
+	if err != nil {
+		t.Fatalf("cannot create scheduler: %v", err)
+	}
+	t.Cleanup(func() { scheduler.Shutdown() })
+
+	repo := marketIndicatorsRepo.New(env.DB)
+	service, err := cryptorank.New(scheduler, env.Server.Client(), repo)
+	if err != nil {
+		t.Fatalf("cannot create service: %v", err)
+	}
+
+	indicator, err := service.GetMarketIndicator()
+	if err != nil {
+		t.Fatalf("market indicator not cached: %v", err)
+	}
+	want := cryptorank.MarketIndicator{
+		FearGreedIndex:          32,
+		FearGreedYesterdayIndex: 28,
+		FearGreedLastWeekIndex:  45,
+		FearGreedLastMonthIndex: 61,
+		BtcDominance:            61.2,
+		EthDominance:            8.1,
+		TotalMarketCap:          2730000000000,
+		TotalVolume24H:          87000000000,
+	}
+	if indicator != want {
+		t.Errorf("market indicator = %+v, want %+v", indicator, want)
+	}
+
+	saved, err := repo.FetchBetween(time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("cannot fetch saved indicators: %v", err)
+	}
+	if len(saved) != 1 {
+		t.Fatalf("saved %d market indicators, want 1", len(saved))
+	}
+	if saved[0].FearGreedIndex != 32 || saved[0].BtcDominance != 61.2 || saved[0].TotalMarketCap != 2730000000000 {
+		t.Errorf("saved market indicator = %+v", saved[0])
+	}
+}
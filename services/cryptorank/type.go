@@ -1,7 +1,11 @@
 package cryptorank
 
 import (
+	"crypto-analytics/models/entities"
 	"crypto-analytics/pkg/observer"
+	marketIndicatorsRepo "crypto-analytics/repositories/marketindicators"
+	"crypto-analytics/utils/insights"
+	"errors"
 	"net/http"
 	"time"
 
@@ -9,16 +13,47 @@ import (
 )
 
 const (
-	cryptorankBaseAPI       = "https://api.cryptorank.io"
-	clientHTTPTimeout       = 15 * time.Second
 	marketIndicatorCacheKey = "marketIndicatorCacheKey"
 )
 
+var (
+	ErrIncompleteMarketIndicator = errors.New("market indicator is incomplete")
+)
+
 type MarketIndicator struct {
 	FearGreedIndex          int     `json:"fearGreedIndex,omitempty"`
 	FearGreedYesterdayIndex int     `json:"fearGreedYesterdayIndex,omitempty"`
+	FearGreedLastWeekIndex  int     `json:"fearGreedLastWeekIndex,omitempty"`
+	FearGreedLastMonthIndex int     `json:"fearGreedLastMonthIndex,omitempty"`
 	BtcDominance            float64 `json:"btcDominance,omitempty"`
+	EthDominance            float64 `json:"ethDominance,omitempty"`
 	TotalMarketCap          int64   `json:"totalMarketCap,omitempty"`
+	TotalVolume24H          int64   `json:"totalVolume24h,omitempty"`
+}
+
+// MarketTrend compares the latest stored indicator with the one stored a window earlier.
+type MarketTrend struct {
+	Current  entities.MarketIndicator
+	Previous entities.MarketIndicator
+}
+
+func (trend MarketTrend) BtcDominanceDelta() float64 {
+	return trend.Current.BtcDominance - trend.Previous.BtcDominance
+}
+
+func (trend MarketTrend) EthDominanceDelta() float64 {
+	return trend.Current.EthDominance - trend.Previous.EthDominance
+}
+
+func (trend MarketTrend) FearGreedDelta() int {
+	return trend.Current.FearGreedIndex - trend.Previous.FearGreedIndex
+}
+
+func (trend MarketTrend) TotalMarketCapChangePercent() float64 {
+	if trend.Previous.TotalMarketCap == 0 {
+		return 0
+	}
+	return float64(trend.Current.TotalMarketCap-trend.Previous.TotalMarketCap) / float64(trend.Previous.TotalMarketCap) * 100
 }
 
 type FearGreedIndex struct {
@@ -47,12 +82,18 @@ type BtcDominance struct {
 
 type Service interface {
 	GetMarketIndicator() (MarketIndicator, error)
+	GetMarketIndicatorHistory(from time.Time, to time.Time) ([]entities.MarketIndicator, error)
+	GetDailyMarketIndicators(from string, to string) ([]entities.MarketIndicator, error)
+	GetMarketTrend(window time.Duration) (MarketTrend, error)
 	RegisterObserver(o observer.Observer)
+	RegisterHealthChecks(probes insights.Probes)
 }
 
 type Impl struct {
 	baseURL   string
 	client    *http.Client
 	cache     *cache.Cache
+	cacheBeat insights.Heartbeat
+	repo      marketIndicatorsRepo.Repository
 	observers map[observer.Observer]struct{}
 }
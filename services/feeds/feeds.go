@@ -6,7 +6,14 @@ import (
 	"crypto-analytics/models/entities"
 	"crypto-analytics/pkg/observer"
 	"crypto-analytics/repositories/feedsources"
+	"crypto-analytics/repositories/news"
+	"crypto-analytics/repositories/usertokens"
+	"crypto-analytics/services/watchlist"
+	"errors"
+	"fmt"
+	"net/http"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/go-co-op/gocron/v2"
@@ -15,26 +22,23 @@ import (
 	"github.com/spf13/viper"
 )
 
-func New(feedSourceRepo feedsources.Repository, scheduler gocron.Scheduler) (*Impl, error) {
-	fp := gofeed.NewParser()
-	fp.UserAgent = viper.GetString(constants.UserAgent)
-	service := &Impl{
-		feedParser:     fp,
-		timeout:        time.Duration(viper.GetInt(constants.RSSTimeout)) * time.Second,
-		feedSourceRepo: feedSourceRepo,
+func New(feedSourceRepo feedsources.Repository, newsRepo news.Repository, userTokensRepo usertokens.Repository, watchlistService watchlist.Service, scheduler gocron.Scheduler, client *http.Client) (*Impl, error) {
+	service, err := NewService(feedSourceRepo, newsRepo, userTokensRepo, watchlistService, client)
+	if err != nil {
+		return nil, err
 	}
-	service.observers = map[observer.Observer]struct{}{}
 
 	_, errJob := scheduler.NewJob(
-		gocron.CronJob("0 8 * * *", true),
-		gocron.NewTask(func() { service.FetchFeeds() }),
+		gocron.CronJob(viper.GetString(constants.FeedsCronTab), true),
+		gocron.NewTask(func() error { return service.FetchFeeds() }),
 		gocron.WithName("Fetch feeds"),
 	)
 	if errJob != nil {
 		return nil, errJob
 	}
 
-	if service.feedSourceRepo.Count() == 0 {
+	// Only on a fresh install, a source removed by the admin must not come back.
+	if service.feedSourceRepo.Count() == 0 && service.newsRepo.Count() == 0 {
 		err := service.feedSourceRepo.Create(entities.FeedSource{FeedTypeID: "cointelegraph", URL: "https://cointelegraph.com/rss", LastUpdate: time.Now().AddDate(0, 0, -5)})
 		if err != nil {
 			log.Error().Err(err).Msg("Error on save feed")
@@ -45,10 +49,34 @@ func New(feedSourceRepo feedsources.Repository, scheduler gocron.Scheduler) (*Im
 
 }
 
+// NewService builds the service without scheduling the reads of the sources, e.g. to manage them from the CLI.
+func NewService(feedSourceRepo feedsources.Repository, newsRepo news.Repository, userTokensRepo usertokens.Repository, watchlistService watchlist.Service, client *http.Client) (*Impl, error) {
+	keywords, errKeywords := loadKeywordsFromConfig()
+	if errKeywords != nil {
+		return nil, errKeywords
+	}
+
+	return &Impl{
+		feedParser:      gofeed.NewParser(),
+		client:          client,
+		userAgent:       viper.GetString(constants.UserAgent),
+		timeout:         time.Duration(viper.GetInt(constants.RSSTimeout)) * time.Second,
+		defaultInterval: viper.GetDuration(constants.FeedsDefaultInterval),
+		retention:       viper.GetDuration(constants.NewsRetention),
+		feedSourceRepo:  feedSourceRepo,
+		newsRepo:        newsRepo,
+		userTokensRepo:  userTokensRepo,
+		watchlist:       watchlistService,
+		keywords:        keywords,
+		observers:       map[observer.Observer]struct{}{},
+	}, nil
+}
+
 func (service *Impl) RegisterObserver(o observer.Observer) {
 	service.observers[o] = struct{}{}
 }
 
+// FetchFeeds reads the sources which are not paused and whose interval elapsed since their last read.
 func (service *Impl) FetchFeeds() error {
 	log.Info().Msgf("Checking feeds...")
 
@@ -57,87 +85,240 @@ func (service *Impl) FetchFeeds() error {
 		return err
 	}
 
+	// Rebuilt on every read to follow the tokens added by the subscribers.
+	symbols, errSymbols := service.userTokensRepo.FetchSymbols()
+	if errSymbols != nil {
+		log.Warn().Err(errSymbols).Msg("Cannot fetch the tokens of the subscribers, only the watchlist is matched")
+	}
+	matcher := NewMatcher(service.watchlist.GetWatchlist(), symbols, service.keywords)
+
+	now := time.Now()
+	var errs []error
 	for _, feedSource := range feedSources {
+		if feedSource.Paused || now.Sub(feedSource.LastFetch) < service.GetInterval(feedSource) {
+			continue
+		}
+		if errCheck := service.checkFeed(feedSource, matcher, now); errCheck != nil {
+			errs = append(errs, fmt.Errorf("feed %s: %w", feedSource.FeedTypeID, errCheck))
+		}
+	}
 
-		service.checkFeed(feedSource)
+	if _, err := service.PurgeNews(); err != nil {
+		log.Error().Err(err).Msg("Cannot purge the old news")
+		errs = append(errs, err)
 	}
-	return nil
+	return errors.Join(errs...)
 }
 
-func (service *Impl) checkFeed(source entities.FeedSource) {
+// checkFeed reads a source and publishes its new items; an unchanged feed is not an error.
+func (service *Impl) checkFeed(source entities.FeedSource, matcher *Matcher, now time.Time) error {
 	log.Info().
 		Str(constants.LogFeedURL, source.URL).
 		Str(constants.LogFeedType, source.FeedTypeID).
 		Msgf("Reading feed source...")
 
-	feed, err := service.readFeed(source.URL)
+	// A failing source also waits for its interval before being read again.
+	source.LastFetch = now
+	feed, err := service.readFeed(&source, now)
 	if err != nil {
-		log.Error().
-			Err(err).
-			Str(constants.LogFeedType, source.FeedTypeID).
-			Str(constants.LogFeedURL, source.URL).
-			Msgf("Cannot parse URL, source ignored")
-		return
+		if errors.Is(err, errNotModified) {
+			log.Info().
+				Str(constants.LogFeedType, source.FeedTypeID).
+				Str(constants.LogFeedURL, source.URL).
+				Msgf("Feed not modified since last read")
+		} else {
+			log.Error().
+				Err(err).
+				Str(constants.LogFeedType, source.FeedTypeID).
+				Str(constants.LogFeedURL, source.URL).
+				Msgf("Cannot parse URL, source ignored")
+		}
+		service.saveSource(source)
+		if errors.Is(err, errNotModified) {
+			return nil
+		}
+		return err
+	}
+
+	sourceName := source.FeedTypeID
+	if feed.Title != "" {
+		sourceName = feed.Title
 	}
 
+	// The first read of a source only records its items, not to flood the subscribers with its backlog.
+	primed := service.newsRepo.CountForSource(source.FeedTypeID) > 0
 	publishedFeeds := 0
 	lastUpdate := source.LastUpdate
+	var errItems error
 	for _, feedItem := range feed.Items {
-		if feedItem.PublishedParsed.UTC().After(lastUpdate.UTC()) {
-			errPublish := service.publishFeedItem(feedItem, feed.Copyright, source)
-			if errPublish != nil {
-				log.Error().Err(err).
-					Str(constants.LogFeedType, source.FeedTypeID).
-					Str(constants.LogFeedURL, source.URL).
-					Str(constants.LogFeedItemID, feedItem.GUID).
-					Msgf("Impossible to publish RSS feed, breaking loop")
-				break
-			}
-
-			if feedItem.PublishedParsed.UTC().After(source.LastUpdate.UTC()) {
-				source.LastUpdate = feedItem.PublishedParsed.UTC()
-			}
-			err = service.feedSourceRepo.Save(source)
-			if err != nil {
-				log.Error().Err(err).
-					Str(constants.LogFeedType, source.FeedTypeID).
-					Str(constants.LogFeedURL, source.URL).
-					Str(constants.LogFeedItemID, feedItem.GUID).
-					Msgf("Impossible to update feed source, breaking loop; this feed might be published again next time")
-				break
-			}
-
-			publishedFeeds++
+		guid := itemGUID(feedItem)
+		if guid == "" {
+			log.Warn().
+				Str(constants.LogFeedType, source.FeedTypeID).
+				Str(constants.LogFeedURL, source.URL).
+				Msgf("Feed item without GUID, link nor title, item ignored")
+			continue
+		}
+
+		seen, errSeen := service.newsRepo.Exists(source.FeedTypeID, guid)
+		if errSeen != nil {
+			log.Error().Err(errSeen).
+				Str(constants.LogFeedType, source.FeedTypeID).
+				Str(constants.LogFeedURL, source.URL).
+				Str(constants.LogFeedItemID, guid).
+				Msgf("Impossible to check feed item, breaking loop")
+			errItems = errSeen
+			break
+		}
+		if seen {
+			continue
+		}
+
+		// Recorded before being published, an item is never sent twice even if its publication fails.
+		publishedAt := itemPublishedAt(feedItem, now)
+		description, content := PlainText(feedItem.Description), PlainText(feedItem.Content)
+		symbols := matcher.Match(feedItem.Title + "\n" + description + "\n" + content)
+		errSave := service.newsRepo.Create(entities.NewsItem{
+			FeedTypeID:  source.FeedTypeID,
+			GUID:        guid,
+			Source:      sourceName,
+			Title:       strings.TrimSpace(feedItem.Title),
+			Link:        feedItem.Link,
+			Summary:     itemSummary(description, content),
+			Categories:  strings.Join(feedItem.Categories, ";"),
+			PublishedAt: publishedAt.UTC(),
+			Symbols:     strings.Join(symbols, ";"),
+		})
+		if errSave != nil {
+			log.Error().Err(errSave).
+				Str(constants.LogFeedType, source.FeedTypeID).
+				Str(constants.LogFeedURL, source.URL).
+				Str(constants.LogFeedItemID, guid).
+				Msgf("Impossible to record feed item, breaking loop")
+			errItems = errSave
+			break
 		}
+		if publishedAt.After(lastUpdate) {
+			lastUpdate = publishedAt
+		}
+
+		// Items mentioning none of the tokens are only kept to be read once.
+		if !primed || len(symbols) == 0 || publishedAt.Before(source.LastUpdate.Add(-lateItemsTolerance)) {
+			continue
+		}
+		service.publishFeedItem(feedItem, sourceName, symbols)
+		publishedFeeds++
 	}
 
+	// Without validators, the items left are downloaded again on the next read.
+	if errItems != nil {
+		source.ETag, source.LastModified = "", ""
+	}
+	if lastUpdate.After(source.LastUpdate) {
+		source.LastUpdate = lastUpdate.UTC()
+	}
+	service.saveSource(source)
+
 	log.Info().
 		Str(constants.LogFeedType, source.FeedTypeID).
 		Str(constants.LogFeedURL, source.URL).
 		Int(constants.LogFeedNumber, publishedFeeds).
+		Bool("primed", primed).
 		Msgf("Feed(s) read and published")
+	return errItems
 }
 
-func (service *Impl) readFeed(url string) (*gofeed.Feed, error) {
+// readFeed downloads and parses a RSS, Atom or JSON feed. The validators of the previous response are sent
+// back and errNotModified is returned when the feed did not change; the source keeps those of the new one.
+func (service *Impl) readFeed(source *entities.FeedSource, now time.Time) (*gofeed.Feed, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), service.timeout)
 	defer cancel()
-	feed, err := service.feedParser.ParseURLWithContext(url, ctx)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source.URL, nil)
 	if err != nil {
 		return nil, err
 	}
+	req.Header.Set("User-Agent", service.userAgent)
+	req.Header.Set("Accept", acceptedFormats)
+	if source.ETag != "" {
+		req.Header.Set("If-None-Match", source.ETag)
+	}
+	if source.LastModified != "" {
+		req.Header.Set("If-Modified-Since", source.LastModified)
+	}
+
+	resp, err := service.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, errNotModified
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("feed request failed with status: %d", resp.StatusCode)
+	}
+
+	feed, err := service.feedParser.Parse(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	source.Format = feed.FeedType
+	source.ETag = resp.Header.Get("ETag")
+	source.LastModified = resp.Header.Get("Last-Modified")
 
 	sort.SliceStable(feed.Items, func(i, j int) bool {
-		return feed.Items[i].PublishedParsed.Before(*feed.Items[j].PublishedParsed)
+		return itemPublishedAt(feed.Items[i], now).Before(itemPublishedAt(feed.Items[j], now))
 	})
 
 	return feed, nil
 }
 
-func (service *Impl) publishFeedItem(item *gofeed.Item, source string,
-	feedSource entities.FeedSource) error {
+func (service *Impl) saveSource(source entities.FeedSource) {
+	if err := service.feedSourceRepo.Save(source); err != nil {
+		log.Error().Err(err).
+			Str(constants.LogFeedType, source.FeedTypeID).
+			Str(constants.LogFeedURL, source.URL).
+			Msgf("Impossible to update feed source")
+	}
+}
+
+func (service *Impl) publishFeedItem(item *gofeed.Item, source string, symbols []string) {
 	for o := range service.observers {
-		o.OnNotify(observer.NewRSSEvent(item))
+		o.OnNotify(observer.NewRSSEvent(source, item, symbols))
+	}
+}
+
+// itemGUID identifies an item within its feed, falling back on its link then its title when the feed has no GUID.
+func itemGUID(item *gofeed.Item) string {
+	for _, id := range []string{item.GUID, item.Link, item.Title} {
+		if id = strings.TrimSpace(id); id != "" {
+			return id
+		}
 	}
+	return ""
+}
 
-	return nil
+// itemPublishedAt falls back on the update date of an item, then on the time it is read, when its date is missing or unparsable.
+func itemPublishedAt(item *gofeed.Item, now time.Time) time.Time {
+	if item.PublishedParsed != nil {
+		return *item.PublishedParsed
+	}
+	if item.UpdatedParsed != nil {
+		return *item.UpdatedParsed
+	}
+	return now
+}
+
+// itemSummary keeps the description of an item, or the start of its content without one, cut to be indexed.
+func itemSummary(description string, content string) string {
+	summary := description
+	if summary == "" {
+		summary = content
+	}
+	if runes := []rune(summary); len(runes) > maxSummaryLength {
+		summary = strings.TrimSpace(string(runes[:maxSummaryLength])) + "…"
+	}
+	return summary
 }
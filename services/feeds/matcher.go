@@ -0,0 +1,91 @@
+package feeds
+
+import (
+	"crypto-analytics/models/constants"
+	"crypto-analytics/models/entities"
+	"crypto-analytics/utils/configs"
+	"html"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	htmlTags   = regexp.MustCompile(`<[^>]*>`)
+	whitespace = regexp.MustCompile(`\s+`)
+	// Trailing "(RLC)" of the description of a watched token.
+	descSymbol = regexp.MustCompile(`\s*\([^)]*\)\s*$`)
+)
+
+// Matcher finds the tokens mentioned by a text.
+type Matcher struct {
+	rules map[string]*regexp.Regexp
+}
+
+// NewMatcher builds a matcher on the names, symbols and handles of the watched tokens, the symbols
+// followed by the subscribers and the configured keywords. Names, handles and keywords are matched
+// whatever their case while symbols must be written in capitals, not to find "SUN" in every "sun".
+func NewMatcher(tokens []entities.WatchedToken, symbols []string, keywords map[string][]string) *Matcher {
+	terms := make(map[string][]string)
+	for _, token := range tokens {
+		if name := descSymbol.ReplaceAllString(token.Desc, ""); name != "" && name != token.Symbol {
+			terms[token.Symbol] = append(terms[token.Symbol], name)
+		}
+		if token.Handle != "" {
+			terms[token.Symbol] = append(terms[token.Symbol], token.Handle)
+		}
+		symbols = append(symbols, token.Symbol)
+	}
+	for symbol, words := range keywords {
+		terms[symbol] = append(terms[symbol], words...)
+		symbols = append(symbols, symbol)
+	}
+
+	rules := make(map[string]*regexp.Regexp)
+	for _, symbol := range symbols {
+		symbol = strings.ToUpper(strings.TrimSpace(symbol))
+		if symbol == "" || rules[symbol] != nil {
+			continue
+		}
+
+		alternatives := []string{regexp.QuoteMeta(symbol)}
+		for _, term := range terms[symbol] {
+			if term = strings.TrimSpace(term); term != "" {
+				alternatives = append(alternatives, "(?i:"+regexp.QuoteMeta(term)+")")
+			}
+		}
+		rules[symbol] = regexp.MustCompile(`(?:^|[^\pL\pN_])(?:` + strings.Join(alternatives, "|") + `)(?:[^\pL\pN_]|$)`)
+	}
+	return &Matcher{rules: rules}
+}
+
+// Match returns the sorted symbols mentioned by a text.
+func (matcher *Matcher) Match(text string) []string {
+	var symbols []string
+	for symbol, rule := range matcher.rules {
+		if rule.MatchString(text) {
+			symbols = append(symbols, symbol)
+		}
+	}
+	sort.Strings(symbols)
+	return symbols
+}
+
+// PlainText turns the HTML of a feed item into a single line of text.
+func PlainText(value string) string {
+	return strings.TrimSpace(whitespace.ReplaceAllString(html.UnescapeString(htmlTags.ReplaceAllString(value, " ")), " "))
+}
+
+func loadKeywordsFromConfig() (map[string][]string, error) {
+	raw, err := configs.Load[map[string][]string](constants.NewsKeywords)
+	if err != nil {
+		return nil, err
+	}
+
+	keywords := make(map[string][]string, len(raw))
+	for symbol, words := range raw {
+		symbol = strings.ToUpper(strings.TrimSpace(symbol))
+		keywords[symbol] = append(keywords[symbol], words...)
+	}
+	return keywords, nil
+}
@@ -0,0 +1,39 @@
+package feeds
+
+import (
+	"crypto-analytics/models/entities"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// FetchNews returns the latest news mentioning a symbol, most recent first.
+func (service *Impl) FetchNews(symbol string, limit int) ([]entities.NewsItem, error) {
+	return service.newsRepo.FetchForSymbol(strings.ToUpper(symbol), min(max(limit, 1), maxSearchLimit))
+}
+
+// Search returns the news of the last 30 days matching every word of the query, most relevant first.
+// A title match weighs more than a summary one, ties being broken by recency.
+func (service *Impl) Search(query string, limit int) ([]entities.NewsItem, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, ErrEmptyQuery
+	}
+	return service.newsRepo.Search(query, time.Now().Add(-searchWindow), min(max(limit, 1), maxSearchLimit))
+}
+
+// PurgeNews deletes the news older than the retention; a retention of zero keeps them forever.
+func (service *Impl) PurgeNews() (int64, error) {
+	if service.retention <= 0 {
+		return 0, nil
+	}
+	deleted, err := service.newsRepo.DeleteOlderThan(time.Now().Add(-service.retention))
+	if err != nil {
+		return 0, err
+	}
+	if deleted > 0 {
+		log.Info().Int64("deleted", deleted).Msg("Old news purged")
+	}
+	return deleted, nil
+}
@@ -0,0 +1,96 @@
+package feeds
+
+import (
+	"crypto-analytics/models/entities"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+func (service *Impl) GetSources() ([]entities.FeedSource, error) {
+	return service.feedSourceRepo.GetFeedSources()
+}
+
+// GetInterval returns the delay between two reads of a source.
+func (service *Impl) GetInterval(source entities.FeedSource) time.Duration {
+	if source.Interval > 0 {
+		return source.Interval
+	}
+	return service.defaultInterval
+}
+
+// AddSource reads a feed before saving it as a source, to reject URLs which are not a RSS, Atom or JSON feed.
+// Its current items are recorded on its first scheduled read without being published.
+func (service *Impl) AddSource(feedTypeID string, rawURL string, interval time.Duration) (entities.FeedSource, error) {
+	feedTypeID = strings.ToLower(strings.TrimSpace(feedTypeID))
+	if feedTypeID == "" || strings.ContainsAny(feedTypeID, " \t\n") {
+		return entities.FeedSource{}, ErrInvalidSourceID
+	}
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return entities.FeedSource{}, ErrInvalidURL
+	}
+	if interval != 0 && interval < minInterval {
+		return entities.FeedSource{}, ErrInvalidInterval
+	}
+	if _, errFind := service.feedSourceRepo.FindByID(feedTypeID); errFind == nil {
+		return entities.FeedSource{}, ErrSourceExists
+	}
+
+	source := entities.FeedSource{FeedTypeID: feedTypeID, URL: parsed.String(), Interval: interval, LastUpdate: time.Now()}
+	feed, errRead := service.readFeed(&source, time.Now())
+	if errRead != nil {
+		return entities.FeedSource{}, fmt.Errorf("cannot read %s: %w", source.URL, errRead)
+	}
+	// Validators are dropped so that the first scheduled read downloads the items to record.
+	source.ETag, source.LastModified = "", ""
+
+	if errCreate := service.feedSourceRepo.Create(source); errCreate != nil {
+		return entities.FeedSource{}, errCreate
+	}
+	log.Info().Str("feedType", source.FeedTypeID).Str("format", source.Format).Int("items", len(feed.Items)).Msg("Feed source added")
+	return source, nil
+}
+
+func (service *Impl) RemoveSource(feedTypeID string) error {
+	source, err := service.findSource(feedTypeID)
+	if err != nil {
+		return err
+	}
+	return service.feedSourceRepo.Delete(source.FeedTypeID)
+}
+
+// PauseSource stops or resumes the reads of a source. Once resumed, the items published while paused
+// are sent within the tolerance of late items.
+func (service *Impl) PauseSource(feedTypeID string, paused bool) error {
+	source, err := service.findSource(feedTypeID)
+	if err != nil {
+		return err
+	}
+	return service.feedSourceRepo.SetPaused(source.FeedTypeID, paused)
+}
+
+// SetSourceInterval changes the delay between two reads of a source, zero meaning the configured default.
+func (service *Impl) SetSourceInterval(feedTypeID string, interval time.Duration) error {
+	if interval != 0 && interval < minInterval {
+		return ErrInvalidInterval
+	}
+	source, err := service.findSource(feedTypeID)
+	if err != nil {
+		return err
+	}
+	return service.feedSourceRepo.SetInterval(source.FeedTypeID, interval)
+}
+
+func (service *Impl) findSource(feedTypeID string) (entities.FeedSource, error) {
+	source, err := service.feedSourceRepo.FindByID(strings.ToLower(strings.TrimSpace(feedTypeID)))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return entities.FeedSource{}, ErrSourceNotFound
+	}
+	return source, err
+}
@@ -1,21 +1,71 @@
 package feeds
 
 import (
+	"crypto-analytics/models/entities"
 	"crypto-analytics/pkg/observer"
 	"crypto-analytics/repositories/feedsources"
+	"crypto-analytics/repositories/news"
+	"crypto-analytics/repositories/usertokens"
+	"crypto-analytics/services/watchlist"
+	"errors"
+	"net/http"
 	"time"
 
 	"github.com/mmcdole/gofeed"
 )
 
+const (
+	// Items published this long before the last update of their source are still read,
+	// feeds sometimes list their items late or with the date of their writing.
+	lateItemsTolerance = 48 * time.Hour
+	// Sources are checked every 5 minutes by default, reading them more often is useless.
+	minInterval = 5 * time.Minute
+	// Accept header of the feed requests, RSS, Atom and JSON Feed being parsed.
+	acceptedFormats = "application/rss+xml, application/atom+xml, application/feed+json, application/json;q=0.9, application/xml;q=0.9, text/xml;q=0.9, */*;q=0.8"
+	// Characters of the summary stored and indexed for each news.
+	maxSummaryLength = 1000
+	// Only the news of the last 30 days are searched, older ones being rarely relevant anymore.
+	searchWindow   = 30 * 24 * time.Hour
+	maxSearchLimit = 100
+)
+
+var (
+	ErrSourceNotFound  = errors.New("feed source not found")
+	ErrSourceExists    = errors.New("feed source already exists")
+	ErrInvalidSourceID = errors.New("feed source id must be a single word")
+	ErrInvalidURL      = errors.New("feed URL must be an absolute http(s) URL")
+	ErrInvalidInterval = errors.New("feed interval must be at least 5m")
+	ErrEmptyQuery      = errors.New("search query is empty")
+
+	// Returned by a conditional read when the feed did not change since the last one.
+	errNotModified = errors.New("feed not modified")
+)
+
 type Service interface {
 	RegisterObserver(o observer.Observer)
 	FetchFeeds() error
+	GetSources() ([]entities.FeedSource, error)
+	AddSource(feedTypeID string, url string, interval time.Duration) (entities.FeedSource, error)
+	RemoveSource(feedTypeID string) error
+	PauseSource(feedTypeID string, paused bool) error
+	SetSourceInterval(feedTypeID string, interval time.Duration) error
+	GetInterval(source entities.FeedSource) time.Duration
+	FetchNews(symbol string, limit int) ([]entities.NewsItem, error)
+	Search(query string, limit int) ([]entities.NewsItem, error)
+	PurgeNews() (int64, error)
 }
 
 type Impl struct {
-	feedParser     *gofeed.Parser
-	timeout        time.Duration
-	feedSourceRepo feedsources.Repository
-	observers      map[observer.Observer]struct{}
+	feedParser      *gofeed.Parser
+	client          *http.Client
+	userAgent       string
+	timeout         time.Duration
+	defaultInterval time.Duration
+	retention       time.Duration
+	feedSourceRepo  feedsources.Repository
+	newsRepo        news.Repository
+	userTokensRepo  usertokens.Repository
+	watchlist       watchlist.Service
+	keywords        map[string][]string
+	observers       map[observer.Observer]struct{}
 }
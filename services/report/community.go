@@ -0,0 +1,49 @@
+package report
+
+import (
+	"crypto-analytics/services/analytics"
+	"crypto-analytics/utils/dates"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+)
+
+// CommunityLines shows the CMC followers and watch count of a symbol with their growth and anomalies.
+func (service *Impl) CommunityLines(symbol string, day time.Time) string {
+	community, err := service.analyticsService.ComputeCommunityGrowth(symbol, day.Format(dates.DateFormat))
+	if err != nil {
+		return ""
+	}
+
+	msg := ""
+	if community.Followers.Value > 0 {
+		msg += fmt.Sprintf("👥 *Followers on CMC:* `%s`%s\n", humanize.Comma(community.Followers.Value), growthSummary(community.Followers))
+	}
+	if community.WatchCount.Value > 0 {
+		msg += fmt.Sprintf("⭐ *Watchlist Count:* `%s`%s\n", humanize.Comma(community.WatchCount.Value), growthSummary(community.WatchCount))
+	}
+	for _, signal := range community.Signals {
+		msg += "⚠️ " + signal + "\n"
+	}
+	return msg
+}
+
+// growthSummary lists the known growths of a counter, e.g. " (`+0.12%` 1d · `+1.05%` 7d)".
+func growthSummary(counter analytics.CounterGrowth) string {
+	var parts []string
+	if counter.HasDaily {
+		parts = append(parts, fmt.Sprintf("`%+.2f%%` 1d", counter.Daily))
+	}
+	if counter.HasWeekly {
+		parts = append(parts, fmt.Sprintf("`%+.2f%%` 7d", counter.Weekly))
+	}
+	if counter.HasMonthly {
+		parts = append(parts, fmt.Sprintf("`%+.2f%%` 30d", counter.Monthly))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(parts, " · ") + ")"
+}
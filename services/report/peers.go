@@ -0,0 +1,42 @@
+package report
+
+import (
+	"crypto-analytics/utils/dates"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// PeerComparison renders, as a fixed width table, how a symbol performed against its competitors.
+func (service *Impl) PeerComparison(symbol string, day time.Time, days int) string {
+	comparison, err := service.analyticsService.ComparePeers(symbol, day.Format(dates.DateFormat), days)
+	if err != nil || len(comparison.Tokens) < 2 {
+		return ""
+	}
+
+	window := strconv.Itoa(days) + "d"
+	msg := fmt.Sprintf("🥊 *%s vs peers* over %s\n", symbol, window)
+	msg += "```\n"
+	msg += fmt.Sprintf("%-6s %8s %6s %5s %6s %7s %4s\n", "Token", window, "Rank", "Δ", "MCap", "Watch", "Hot")
+	for _, stats := range comparison.Tokens {
+		returns, watch := "-", "-"
+		if stats.HasReturn {
+			returns = fmt.Sprintf("%+.1f%%", stats.Return)
+		}
+		if stats.HasWatchCountGrowth {
+			watch = fmt.Sprintf("%+.1f%%", stats.WatchCountGrowth)
+		}
+		rankDelta := "-"
+		if stats.PreviousRank > 0 && stats.Rank > 0 {
+			rankDelta = fmt.Sprintf("%+d", stats.PreviousRank-stats.Rank)
+		}
+		msg += fmt.Sprintf("%-6s %8s %6s %5s %5.2fx %7s %4d\n",
+			stats.Symbol, returns, "#"+strconv.Itoa(stats.Rank), rankDelta, stats.MarketcapRatio, watch, stats.TrendingDays)
+	}
+	msg += "```\n"
+
+	if subject := comparison.Tokens[0]; subject.HasFollowersGrowth {
+		msg += fmt.Sprintf("👥 %s followers over %s: `%+.2f%%`\n", subject.Symbol, window, subject.FollowersGrowth)
+	}
+	return msg
+}
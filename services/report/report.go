@@ -0,0 +1,288 @@
+package report
+
+import (
+	"crypto-analytics/models/entities"
+	"crypto-analytics/services/analytics"
+	cmcService "crypto-analytics/services/coinmarketcap"
+	twitterService "crypto-analytics/services/twitter"
+	"crypto-analytics/services/watchlist"
+	"crypto-analytics/utils/dates"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/patrickmn/go-cache"
+	"github.com/rs/zerolog/log"
+)
+
+func New(cmcService cmcService.Service, twitterService twitterService.Service, watchlistService watchlist.Service, analyticsService analytics.Service) *Impl {
+	return &Impl{
+		cmcService:       cmcService,
+		twitterService:   twitterService,
+		watchlistService: watchlistService,
+		analyticsService: analyticsService,
+		cache:            cache.New(1*time.Hour, 2*time.Hour),
+	}
+}
+
+// Generate refreshes the cached sections of the daily report, based on yesterday's data.
+func (service *Impl) Generate() {
+	log.Info().Msg("Generate daily report")
+	yesterday := time.Now().AddDate(0, 0, -1)
+	service.cache.Set(overviewCacheKey, service.generateOverview(yesterday), cache.NoExpiration)
+	for _, token := range service.watchlistService.GetWatchlist() {
+		section, ok := service.generateTokenReport(token, yesterday)
+		if ok {
+			service.cache.Set(tokenCacheKey(token.Symbol), section, cache.NoExpiration)
+		} else {
+			service.cache.Delete(tokenCacheKey(token.Symbol))
+		}
+	}
+}
+
+// Render assembles the cached overview with one section per token;
+// sections of tokens outside the global watchlist are generated on demand.
+// The live quotes are left out of the cache, being refreshed every few minutes.
+func (service *Impl) Render(tokens []entities.WatchedToken) (string, bool) {
+	yesterday := time.Now().AddDate(0, 0, -1)
+	overview, found := service.cache.Get(overviewCacheKey)
+	if !found {
+		overview = service.generateOverview(yesterday)
+		service.cache.Set(overviewCacheKey, overview, cache.NoExpiration)
+	}
+
+	ok := false
+	msg := overview.(string)
+	for _, token := range tokens {
+		if section, cached := service.cache.Get(tokenCacheKey(token.Symbol)); cached {
+			msg += section.(tokenSection).render(service.LiveQuoteLine(token.Symbol))
+			ok = true
+			continue
+		}
+
+		section, generated := service.generateTokenReport(token, yesterday)
+		if generated {
+			service.cache.SetDefault(tokenCacheKey(token.Symbol), section)
+			msg += section.render(service.LiveQuoteLine(token.Symbol))
+			ok = true
+		}
+	}
+
+	msg += "\n"
+	msg += "📆 Data from *yesterday*. Stay tuned for more updates! 📈\n\n"
+	msg += "⚠️ The report is based on yesterday's data, so 7-day data actually means today minus 8 days.\n"
+	msg += "⚡ Live prices are refreshed every few minutes.\n"
+
+	return msg, ok
+}
+
+// RenderForDay builds, without any cache, the report as it was for the given data day.
+func (service *Impl) RenderForDay(tokens []entities.WatchedToken, day time.Time) (string, bool) {
+	ok := false
+	msg := service.generateOverview(day)
+	for _, token := range tokens {
+		section, generated := service.generateTokenReport(token, day)
+		if generated {
+			msg += section.render("")
+			ok = true
+		}
+	}
+
+	msg += "\n"
+	msg += fmt.Sprintf("📆 Data from *%s*.\n", day.Format(dates.DateFormat))
+
+	return msg, ok
+}
+
+func (service *Impl) LiveQuoteLine(symbol string) string {
+	quote, err := service.cmcService.FetchLiveQuote(symbol)
+	if err != nil {
+		return ""
+	}
+
+	return fmt.Sprintf("⚡ Live: `$%s` (`%+.2f%%` 24h) at `%s`\n",
+		FormatPrice(quote.Price), quote.PercentChange24h, quote.Timestamp.In(time.Local).Format("15:04"))
+}
+
+// TechnicalLines summarises the technical indicators of a symbol at a data day, followed by its signals.
+func (service *Impl) TechnicalLines(symbol string, day time.Time) string {
+	technicals, err := service.analyticsService.Compute(symbol, day.Format(dates.DateFormat))
+	if err != nil {
+		return ""
+	}
+
+	msg := ""
+	if technicals.RSI > 0 {
+		msg += fmt.Sprintf("📐 RSI: `%.0f`", technicals.RSI)
+		if technicals.Volatility > 0 {
+			msg += fmt.Sprintf(" · Volatility 30d: `%.0f%%`", technicals.Volatility*100)
+		}
+		msg += "\n"
+	}
+	if technicals.MaxDrawdown < 0 {
+		msg += fmt.Sprintf("🕳 Max drawdown since halving: `%.1f%%`\n", technicals.MaxDrawdown*100)
+	}
+	for _, signal := range technicals.Signals {
+		msg += "🔔 " + signal + "\n"
+	}
+	return msg
+}
+
+// SectorLines compares the return of a symbol over the last week with the median of each of its sectors.
+func (service *Impl) SectorLines(symbol string, day time.Time) string {
+	performances, err := service.analyticsService.ComputeSectorPerformance(day.Format(dates.DateFormat), sectorDays)
+	if err != nil {
+		return ""
+	}
+
+	msg := ""
+	for _, performance := range performances {
+		for _, member := range performance.Members {
+			if member.Symbol != symbol || !member.HasReturn {
+				continue
+			}
+			msg += fmt.Sprintf("🧩 %s sector `%+.2f%%` this week, %s `%+.2f%%`\n", performance.Name, performance.MedianReturn, symbol, member.Return)
+			break
+		}
+	}
+	return msg
+}
+
+func (service *Impl) generateOverview(day time.Time) string {
+	dayData := day.Format(dates.DateFormat)
+	dayBefore := day.AddDate(0, 0, -1).Format(dates.DateFormat)
+	msg := "📢 *Daily Crypto Report* 🚀\n\n"
+
+	msg += "📈 *Maket Overview this last 2 days*\n"
+
+	yesterdayBTC, err := service.cmcService.FetchForSymbolForDay("BTC", dayData)
+	twoDaysBTC, err2 := service.cmcService.FetchForSymbolForDay("BTC", dayBefore)
+	if err == nil && err2 == nil {
+		msg += GenerateTokenSentence("BTC", yesterdayBTC.Price, twoDaysBTC.Price) + "\n" //fmt.Sprintf("💰 BTC Price: `$%.2f`\n", histo.Price)
+	}
+	yesterdayETH, err := service.cmcService.FetchForSymbolForDay("ETH", dayData)
+	twoDaysETH, err2 := service.cmcService.FetchForSymbolForDay("ETH", dayBefore)
+	if err == nil && err2 == nil {
+		msg += GenerateTokenSentence("ETH", yesterdayETH.Price, twoDaysETH.Price) + "\n\n" //fmt.Sprintf("💰 BTC Price: `$%.2f`\n", histo.Price)
+	}
+	movers, errMovers := service.cmcService.GetMovers(dayData, 1, reportMoversCount)
+	if errMovers == nil && (len(movers.Gainers) > 0 || len(movers.Losers) > 0) {
+		msg += "🚀 *Top movers of the day*\n"
+		for _, gainer := range movers.Gainers {
+			msg += fmt.Sprintf("- %s `%+.2f%%`\n", gainer.Symbol, gainer.PercentChange)
+		}
+		for _, loser := range movers.Losers {
+			msg += fmt.Sprintf("- %s `%+.2f%%`\n", loser.Symbol, loser.PercentChange)
+		}
+	} else if errMovers != nil {
+		log.Warn().Err(errMovers).Msg("No movers for the report")
+	}
+
+	msg += "\n"
+	msg += "👉 *Focus on tokens*\n\n"
+	return msg
+}
+
+func (service *Impl) generateTokenReport(crycryptocurrency entities.WatchedToken, day time.Time) (tokenSection, bool) {
+	dayData := day.Format(dates.DateFormat)
+	sevenDaysBefore := day.AddDate(0, 0, -7).Format(dates.DateFormat)
+	ok := false
+	section := tokenSection{}
+	msg := "🔹 *" + crycryptocurrency.Desc + "*\n"
+	histo, errPrice := service.fetchTokenForDay(crycryptocurrency, dayData)
+	histo7DaysAgo, errPrice7Days := service.fetchTokenForDay(crycryptocurrency, sevenDaysBefore)
+	trendy := service.cmcService.IsCryptoTrendyAtDay(crycryptocurrency.Symbol, dayData)
+
+	if errPrice == nil {
+
+		msg += fmt.Sprintf("💰 Price: `$%.2f`\n", histo.Price)
+		section.head, msg = msg, ""
+		if errPrice7Days == nil {
+			percent := ((histo.Price - histo7DaysAgo.Price) / histo7DaysAgo.Price) * 100
+			if percent < 0 {
+				msg += fmt.Sprintf("📉 7 days : `%.2f%%`\n", percent)
+			} else {
+				msg += fmt.Sprintf("📈 7 days : `%.2f%%`\n", percent)
+			}
+
+		}
+		msg += fmt.Sprintf("📊 Rank: `#%d`\n", histo.Rank)
+		msg += fmt.Sprintf("🏛 Market Cap: `$%s`\n", humanize.CommafWithDigits(histo.Marketcap, 2))
+		//fmt.Sprintf("🏛 Market Cap: `$%.2f`\n", histo.Marketcap)
+		msg += service.TechnicalLines(crycryptocurrency.Symbol, day)
+		msg += service.SectorLines(crycryptocurrency.Symbol, day)
+		if len(service.watchlistService.GetPeers(crycryptocurrency.Symbol)) > 0 {
+			msg += service.PeerComparison(crycryptocurrency.Symbol, day, peerDays)
+		}
+		ok = true
+	}
+	if trendy {
+		msg += fmt.Sprintf("🔥 Trending: *%s*\n\n", "Yes! 🚀")
+	} else {
+		msg += fmt.Sprintf("🔥 Trending: *%s*\n\n", "No ❄️")
+	}
+	msg += service.CommunityLines(crycryptocurrency.Symbol, day)
+
+	//degeu
+	if histo.Symbol == "RLC" {
+		tweets, errTweets := service.twitterService.GetTweetsForDay(day)
+		if errTweets == nil && len(tweets) > 0 {
+			msg += "🔥 *Twitter Highlights from Yesterday*\n\n"
+			for _, tweet := range tweets {
+				msg += "🔗 [Tweet Link](" + tweet.PermanentURL + ")\n"
+			}
+
+		} else {
+			msg += "No Twitter activity yesterday.\n"
+		}
+	}
+
+	msg += "\n"
+	section.body = msg
+	return section, ok
+}
+
+// render inserts the live quote line, if any, after the price of the section.
+func (section tokenSection) render(liveQuote string) string {
+	return section.head + liveQuote + section.body
+}
+
+// fetchTokenForDay looks a token up by its CMC id when known, its symbol may be shared with other tokens.
+func (service *Impl) fetchTokenForDay(token entities.WatchedToken, day string) (entities.Historical, error) {
+	if token.CryptoID > 0 {
+		return service.cmcService.FetchForIDForDay(token.CryptoID, day)
+	}
+	return service.cmcService.FetchForSymbolForDay(token.Symbol, day)
+}
+
+func tokenCacheKey(symbol string) string {
+	return "daily_report_" + symbol
+}
+
+// GenerateTokenSentence generates a sentence describing the token's performance
+func GenerateTokenSentence(symbol string, yesterdayPrice, twoDaysAgoPrice float64) string {
+	// Compute the percentage change over 2 days
+	percentChange := ((yesterdayPrice - twoDaysAgoPrice) / twoDaysAgoPrice) * 100
+
+	// Get the proper name ($BTC or $ETH)
+	tokenName := fmt.Sprintf("$%s", symbol)
+
+	// Generate sentence based on percentage change
+	if math.Abs(percentChange) <= 2 {
+		return fmt.Sprintf("%s remains stable at $%.0f, with a slight %.2f%% move over the past two days.", tokenName, yesterdayPrice, percentChange)
+	} else if percentChange > 2 {
+		return fmt.Sprintf("%s continues its bullish momentum, rising to $%.0f (+%.2f%%) in the last two days.", tokenName, yesterdayPrice, percentChange)
+	} else {
+		return fmt.Sprintf("%s is facing some pressure, dropping to $%.0f (-%.2f%%) over the last two days.", tokenName, yesterdayPrice, math.Abs(percentChange))
+	}
+}
+
+// FormatPrice keeps more decimals for tokens priced below one dollar.
+func FormatPrice(price float64) string {
+	if price < 1 {
+		return strconv.FormatFloat(price, 'f', 4, 64)
+	}
+	return strconv.FormatFloat(price, 'f', 2, 64)
+}
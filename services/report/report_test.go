@@ -0,0 +1,49 @@
+package report_test
+
+import (
+	"crypto-analytics/application/apptest"
+	"crypto-analytics/models/constants"
+	twitterRepo "crypto-analytics/repositories/twitter"
+	"crypto-analytics/services/analytics"
+	"crypto-analytics/services/report"
+	twitterService "crypto-analytics/services/twitter"
+	"crypto-analytics/utils/dates"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderForDay(t *testing.T) {
+	env := apptest.New(t, nil)
+	cmc := env.CoinMarketCap()
+	twitter := twitterService.NewService(twitterRepo.New(env.DB), constants.GetTwitterAccounts())
+	service := report.New(cmc, twitter, env.Watchlist, analytics.New(cmc, env.Sectors, env.Watchlist))
+
+	day := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.Local)
+	progress, err := cmc.Backfill(day.AddDate(0, 0, -7).Format(dates.DateFormat), day.Format(dates.DateFormat), false)
+	if err != nil {
+		t.Fatalf("cannot backfill: %v", err)
+	}
+	if progress.FailedPages > 0 {
+		t.Fatalf("%d pages failed to backfill", progress.FailedPages)
+	}
+
+	msg, ok := service.RenderForDay(env.Watchlist.GetWatchlist(), day)
+	if !ok {
+		t.Fatalf("no token in the report:\n%s", msg)
+	}
+	for _, want := range []string{
+		"$BTC remains stable at $84000",
+		"🔹 *iExec RLC (RLC)*\n💰 Price: `$1.05`\n📈 7 days : `0.00%`\n📊 Rank: `#240`\n",
+		"🔹 *Phala Network (PHA)*\n💰 Price: `$0.11`\n",
+		"🔹 *Secret Network (SCRT)*\n💰 Price: `$0.19`\n",
+		"📆 Data from *2025-03-01*.",
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("report does not contain %q:\n%s", want, msg)
+		}
+	}
+	if strings.Contains(msg, "⚡ Live") {
+		t.Errorf("report of a past day contains a live quote:\n%s", msg)
+	}
+}
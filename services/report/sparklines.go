@@ -0,0 +1,36 @@
+package report
+
+import (
+	"bytes"
+	"crypto-analytics/models/entities"
+	"crypto-analytics/pkg/chart"
+	"crypto-analytics/utils/dates"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// RenderSparklines draws the price of the last days of each token as a PNG grid.
+func (service *Impl) RenderSparklines(tokens []entities.WatchedToken) ([]byte, error) {
+	to := time.Now().AddDate(0, 0, -1)
+	from := to.AddDate(0, 0, -sparklineDays)
+
+	sparklines := make([]chart.Sparkline, 0, len(tokens))
+	for _, token := range tokens {
+		historicals, err := service.cmcService.FetchForSymbolBetween(token.Symbol, from.Format(dates.DateFormat), to.Format(dates.DateFormat))
+		if err != nil {
+			log.Error().Err(err).Str("symbol", token.Symbol).Msg("Cannot fetch price history for sparkline")
+			continue
+		}
+		sparklines = append(sparklines, chart.Sparkline{Label: token.Symbol, Points: chart.PricePoints(historicals)})
+	}
+
+	var image bytes.Buffer
+	grid := chart.SparklineGrid{Title: fmt.Sprintf("Price over the last %d days", sparklineDays), Sparklines: sparklines}
+	if err := grid.Render(&image); err != nil {
+		return nil, err
+	}
+
+	return image.Bytes(), nil
+}
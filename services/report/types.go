@@ -0,0 +1,50 @@
+package report
+
+import (
+	"crypto-analytics/models/entities"
+	"crypto-analytics/services/analytics"
+	cmcService "crypto-analytics/services/coinmarketcap"
+	twitterService "crypto-analytics/services/twitter"
+	"crypto-analytics/services/watchlist"
+	"time"
+
+	"github.com/patrickmn/go-cache"
+)
+
+const (
+	overviewCacheKey = "daily_report"
+	// Days of price history drawn in each sparkline of the report.
+	sparklineDays = 30
+	// Gainers and losers listed in the overview.
+	reportMoversCount = 3
+	// Window of the sector comparison of a token.
+	sectorDays = 7
+	// Window of the comparison with competitors in the report.
+	peerDays = 7
+)
+
+// tokenSection is the report of a token, split after its price to insert its live quote on render.
+type tokenSection struct {
+	head string
+	body string
+}
+
+type Service interface {
+	Generate()
+	Render(tokens []entities.WatchedToken) (string, bool)
+	RenderForDay(tokens []entities.WatchedToken, day time.Time) (string, bool)
+	LiveQuoteLine(symbol string) string
+	TechnicalLines(symbol string, day time.Time) string
+	SectorLines(symbol string, day time.Time) string
+	PeerComparison(symbol string, day time.Time, days int) string
+	CommunityLines(symbol string, day time.Time) string
+	RenderSparklines(tokens []entities.WatchedToken) ([]byte, error)
+}
+
+type Impl struct {
+	cmcService       cmcService.Service
+	twitterService   twitterService.Service
+	watchlistService watchlist.Service
+	analyticsService analytics.Service
+	cache            *cache.Cache
+}
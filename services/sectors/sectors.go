@@ -0,0 +1,80 @@
+package sectors
+
+import (
+	"crypto-analytics/models/constants"
+	"crypto-analytics/models/entities"
+	"crypto-analytics/utils/configs"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+func New() (*Impl, error) {
+	sectors, err := configs.Load[[]entities.Sector](constants.Sectors)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range sectors {
+		sectors[i].Name = strings.TrimSpace(sectors[i].Name)
+		for j, pattern := range sectors[i].Patterns {
+			sectors[i].Patterns[j] = NormalizeTag(pattern)
+		}
+	}
+
+	log.Info().Int("sectors", len(sectors)).Msg("Sectors loaded")
+	return &Impl{sectors: sectors}, nil
+}
+
+func (service *Impl) GetSectors() []entities.Sector {
+	return service.sectors
+}
+
+func (service *Impl) FindByName(name string) (entities.Sector, bool) {
+	for _, sector := range service.sectors {
+		if strings.EqualFold(sector.Name, strings.TrimSpace(name)) {
+			return sector, true
+		}
+	}
+	return entities.Sector{}, false
+}
+
+func (service *Impl) Match(tags []string) []string {
+	var names []string
+	for _, sector := range service.sectors {
+		if matchesAny(sector, tags) {
+			names = append(names, sector.Name)
+		}
+	}
+	return names
+}
+
+func (service *Impl) RelevantTags(tags []string) []string {
+	var relevants []string
+	for _, tag := range tags {
+		for _, sector := range service.sectors {
+			if matchesAny(sector, []string{tag}) {
+				relevants = append(relevants, NormalizeTag(tag))
+				break
+			}
+		}
+	}
+	return relevants
+}
+
+// NormalizeTag lowercases a CMC tag, e.g. "AI-Big-Data" and "ai-big-data" are the same tag.
+func NormalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
+
+func matchesAny(sector entities.Sector, tags []string) bool {
+	for _, tag := range tags {
+		tag = NormalizeTag(tag)
+		for _, pattern := range sector.Patterns {
+			if pattern != "" && strings.Contains(tag, pattern) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,18 @@
+package sectors
+
+import (
+	"crypto-analytics/models/entities"
+)
+
+type Service interface {
+	GetSectors() []entities.Sector
+	FindByName(name string) (entities.Sector, bool)
+	// Match returns the names of the sectors of a token from its tags.
+	Match(tags []string) []string
+	// RelevantTags keeps the tags belonging to at least one sector.
+	RelevantTags(tags []string) []string
+}
+
+type Impl struct {
+	sectors []entities.Sector
+}
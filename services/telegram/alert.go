@@ -0,0 +1,121 @@
+package telegram
+
+import (
+	"crypto-analytics/models/entities"
+	"crypto-analytics/services/alerts"
+	"crypto-analytics/services/report"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/rs/zerolog/log"
+)
+
+// alertCmd manages the price alerts of a subscriber:
+// /alert add <SYMBOL> above|below <price>, /alert add <SYMBOL> move <percent>,
+// /alert list, /alert delete <id>.
+func (service *Impl) alertCmd(b *gotgbot.Bot, ctx *ext.Context) error {
+	log.Info().Str("cmd", "alert").Str("username", ctx.EffectiveChat.Username).Int64("chatID", ctx.EffectiveChat.Id).Msg("command received")
+
+	if !service.isASubscriber(ctx.EffectiveChat.Id) {
+		msg := "⚠️ This feature is only available for subscribers !\n"
+		service.sendMessage("alert", ctx.EffectiveChat.Id, msg)
+		return nil
+	}
+
+	args := strings.Fields(ctx.Message.GetText())[1:]
+	var msg string
+	switch {
+	case len(args) == 0 || strings.EqualFold(args[0], "list"):
+		msg = service.alertsMessage(ctx.EffectiveChat.Id)
+	case strings.EqualFold(args[0], "add") && len(args) == 4:
+		msg = service.addAlert(ctx.EffectiveChat.Id, args[1], args[2], args[3])
+	case strings.EqualFold(args[0], "delete") && len(args) == 2:
+		msg = service.deleteAlert(ctx.EffectiveChat.Id, args[1])
+	default:
+		msg = "Usage: `/alert add <SYMBOL> above|below <price>`, `/alert add <SYMBOL> move <percent>`, `/alert list` or `/alert delete <id>`"
+	}
+
+	service.sendMessage("alert", ctx.EffectiveChat.Id, msg)
+	return nil
+}
+
+func (service *Impl) addAlert(chatID int64, symbol, kind, value string) string {
+	threshold, err := strconv.ParseFloat(strings.Trim(value, "$%+"), 64)
+	if err != nil {
+		return "⚠️ The threshold must be a number."
+	}
+
+	alert, err := service.alertsService.Add(chatID, symbol, kind, threshold)
+	if err != nil {
+		log.Warn().Err(err).Int64("chatID", chatID).Str("symbol", symbol).Msg("cannot add alert")
+		if errors.Is(err, alerts.ErrUnknownSymbol) {
+			return fmt.Sprintf("⚠️ *%s* is unknown (only TOP 1000 tokens are available).", strings.ToUpper(symbol))
+		}
+		return "⚠️ Cannot add this alert: " + err.Error()
+	}
+
+	return "🔔 Alert created: " + describeAlert(alert)
+}
+
+func (service *Impl) deleteAlert(chatID int64, value string) string {
+	id, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return "⚠️ The alert id must be a number."
+	}
+
+	if errDelete := service.alertsService.Delete(chatID, uint(id)); errDelete != nil {
+		if errors.Is(errDelete, alerts.ErrAlertNotFound) {
+			return "⚠️ This alert does not exist."
+		}
+		log.Error().Err(errDelete).Int64("chatID", chatID).Msg("cannot delete alert")
+		return getGenericErrorMEssage()
+	}
+
+	return fmt.Sprintf("🗑 Alert `#%d` deleted.", id)
+}
+
+func (service *Impl) alertsMessage(chatID int64) string {
+	userAlerts, err := service.alertsService.ListForUser(chatID)
+	if err != nil || len(userAlerts) == 0 {
+		return "🔕 You have no alert.\n\nUse `/alert add <SYMBOL> above <price>` to create one."
+	}
+
+	msg := "🔔 *Your alerts*\n\n"
+	for _, alert := range userAlerts {
+		msg += describeAlert(alert) + "\n"
+	}
+	return msg
+}
+
+func (service *Impl) sendAlerts() {
+	for _, trigger := range service.alertsService.Evaluate() {
+		msg := "🚨 *Price Alert!* 🔔\n\n"
+		switch trigger.Alert.Kind {
+		case alerts.KindAbove:
+			msg += fmt.Sprintf("📈 *%s* is above `$%s`: now at `$%s`\n", trigger.Alert.Symbol, report.FormatPrice(trigger.Alert.Threshold), report.FormatPrice(trigger.Price))
+		case alerts.KindBelow:
+			msg += fmt.Sprintf("📉 *%s* is below `$%s`: now at `$%s`\n", trigger.Alert.Symbol, report.FormatPrice(trigger.Alert.Threshold), report.FormatPrice(trigger.Price))
+		default:
+			msg += fmt.Sprintf("🎢 *%s* moved `%+.2f%%` in 24h: now at `$%s`\n", trigger.Alert.Symbol, trigger.PercentChange, report.FormatPrice(trigger.Price))
+		}
+		msg += fmt.Sprintf("\nUse `/alert delete %d` to stop this alert.", trigger.Alert.ID)
+
+		log.Info().Int64("chatID", trigger.Alert.ChatID).Uint("alertID", trigger.Alert.ID).Msg("send price alert")
+		service.sendMessage("alert_notification", trigger.Alert.ChatID, msg)
+	}
+}
+
+func describeAlert(alert entities.PriceAlert) string {
+	switch alert.Kind {
+	case alerts.KindAbove:
+		return fmt.Sprintf("`#%d` *%s* above `$%s`", alert.ID, alert.Symbol, report.FormatPrice(alert.Threshold))
+	case alerts.KindBelow:
+		return fmt.Sprintf("`#%d` *%s* below `$%s`", alert.ID, alert.Symbol, report.FormatPrice(alert.Threshold))
+	default:
+		return fmt.Sprintf("`#%d` *%s* moves ±`%.2f%%` in 24h", alert.ID, alert.Symbol, alert.Threshold)
+	}
+}
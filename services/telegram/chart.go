@@ -0,0 +1,126 @@
+package telegram
+
+import (
+	"bytes"
+	"crypto-analytics/models/entities"
+	"crypto-analytics/pkg/chart"
+	cmcService "crypto-analytics/services/coinmarketcap"
+	"crypto-analytics/services/report"
+	"crypto-analytics/utils/dates"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/dustin/go-humanize"
+	"github.com/rs/zerolog/log"
+)
+
+// chartCmd sends the history of a token as an image: /chart SYMBOL [7d|30d|90d|since-halving] [price|candles|rank|mcap].
+func (service *Impl) chartCmd(b *gotgbot.Bot, ctx *ext.Context) error {
+	log.Info().Str("cmd", "chart").Str("username", ctx.EffectiveChat.Username).Int64("chatID", ctx.EffectiveChat.Id).Msg("command received")
+
+	if !service.isASubscriber(ctx.EffectiveChat.Id) {
+		msg := "⚠️ This feature is only available for subscribers !\n"
+		service.sendMessage("chart", ctx.EffectiveChat.Id, msg)
+		return nil
+	}
+
+	usage := "Usage: `/chart <symbol> [7d|30d|90d|since-halving] [price|candles|rank|mcap]`"
+	args := strings.Fields(ctx.Message.GetText())[1:]
+	if len(args) == 0 || len(args) > 3 {
+		service.sendMessage("chart", ctx.EffectiveChat.Id, usage)
+		return nil
+	}
+
+	symbol, window, kind := strings.ToUpper(args[0]), defaultChartWindow, defaultChartKind
+	for _, arg := range args[1:] {
+		arg = strings.ToLower(arg)
+		if _, found := chartWindows[arg]; found {
+			window = arg
+		} else if chartKinds[arg] {
+			kind = arg
+		} else {
+			service.sendMessage("chart", ctx.EffectiveChat.Id, usage)
+			return nil
+		}
+	}
+
+	to := time.Now().AddDate(0, 0, -1)
+	from := cmcService.HalvingDate
+	if days := chartWindows[window]; days > 0 {
+		from = to.AddDate(0, 0, -days).Format(dates.DateFormat)
+	}
+
+	historicals, err := service.cmcService.FetchForSymbolBetween(symbol, from, to.Format(dates.DateFormat))
+	if err != nil {
+		log.Error().Err(err).Str("cmd", "chart").Str("symbol", symbol).Msg("cannot fetch history")
+		service.sendMessage("chart", ctx.EffectiveChat.Id, getGenericErrorMEssage())
+		return nil
+	}
+
+	var image bytes.Buffer
+	if errChart := renderHistoricalChart(&image, symbol, window, kind, historicals); errChart != nil {
+		service.sendMessage("chart", ctx.EffectiveChat.Id, fmt.Sprintf("📉 Not enough history for *%s* yet (only TOP 1000).", symbol))
+		return nil
+	}
+
+	first, last := historicals[0], historicals[len(historicals)-1]
+	caption := fmt.Sprintf("📊 *%s* (%s) from `%s` to `%s`\n", last.Name, last.Symbol, first.Day, last.Day)
+	caption += fmt.Sprintf("💰 Price: `$%s` (`%+.2f%%`)\n", report.FormatPrice(last.Price), percentChange(first.Price, last.Price))
+	caption += fmt.Sprintf("🏆 Rank: `#%d` (was `#%d`)\n", last.Rank, first.Rank)
+	caption += fmt.Sprintf("🏛 Market Cap: `$%s`\n", humanize.SIWithDigits(last.Marketcap, 2, ""))
+	service.sendPhoto("chart", ctx.EffectiveChat.Id, "chart.png", &image, caption)
+	return nil
+}
+
+// renderHistoricalChart draws the requested kind of chart. Candles are daily up to 30 days,
+// then grouped so that the chart stays readable.
+func renderHistoricalChart(image *bytes.Buffer, symbol string, window string, kind string, historicals []entities.Historical) error {
+	formatPrice := func(value float64) string { return "$" + report.FormatPrice(value) }
+
+	switch kind {
+	case "rank":
+		return chart.LineChart{
+			Title:       fmt.Sprintf("%s rank - %s", symbol, window),
+			Series:      []chart.Series{{Name: "Rank", Color: chart.Purple, Points: chart.RankPoints(historicals)}},
+			InvertY:     true,
+			FormatValue: func(value float64) string { return "#" + strconv.Itoa(int(value)) },
+		}.Render(image)
+	case "mcap":
+		return chart.LineChart{
+			Title:       fmt.Sprintf("%s market cap - %s", symbol, window),
+			Series:      []chart.Series{{Name: "Market cap", Color: chart.Orange, Points: chart.MarketcapPoints(historicals)}},
+			FormatValue: func(value float64) string { return "$" + humanize.SIWithDigits(value, 1, "") },
+		}.Render(image)
+	case "candles":
+		points := chart.PricePoints(historicals)
+		size := 1
+		switch {
+		case len(points) > 90:
+			size = 7
+		case len(points) > 30:
+			size = 3
+		}
+		return chart.CandlestickChart{
+			Title:       fmt.Sprintf("%s price - %s (%d-day candles)", symbol, window, size),
+			Candles:     chart.GroupCandles(points, size),
+			FormatValue: formatPrice,
+		}.Render(image)
+	default:
+		return chart.LineChart{
+			Title:       fmt.Sprintf("%s price - %s", symbol, window),
+			Series:      []chart.Series{{Name: "Price", Color: chart.Blue, Points: chart.PricePoints(historicals)}},
+			FormatValue: formatPrice,
+		}.Render(image)
+	}
+}
+
+func percentChange(from float64, to float64) float64 {
+	if from == 0 {
+		return 0
+	}
+	return (to - from) / from * 100
+}
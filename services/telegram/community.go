@@ -0,0 +1,79 @@
+package telegram
+
+import (
+	"crypto-analytics/services/analytics"
+	"crypto-analytics/utils/dates"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/dustin/go-humanize"
+	"github.com/rs/zerolog/log"
+)
+
+// communityCmd details the growth of the CMC followers and watch count of a token: /community SYMBOL.
+func (service *Impl) communityCmd(b *gotgbot.Bot, ctx *ext.Context) error {
+	log.Info().Str("cmd", "community").Str("username", ctx.EffectiveChat.Username).Int64("chatID", ctx.EffectiveChat.Id).Msg("command received")
+
+	if !service.isASubscriber(ctx.EffectiveChat.Id) {
+		msg := "⚠️ This feature is only available for subscribers !\n"
+		service.sendMessage("community", ctx.EffectiveChat.Id, msg)
+		return nil
+	}
+
+	args := strings.Fields(ctx.Message.GetText())[1:]
+	if len(args) != 1 {
+		service.sendMessage("community", ctx.EffectiveChat.Id, "Usage: `/community <symbol>`")
+		return nil
+	}
+
+	// The community job runs once a day, today's counters may not be fetched yet.
+	symbol := strings.ToUpper(args[0])
+	community, err := service.analyticsService.ComputeCommunityGrowth(symbol, time.Now().Format(dates.DateFormat))
+	if err != nil {
+		community, err = service.analyticsService.ComputeCommunityGrowth(symbol, time.Now().AddDate(0, 0, -1).Format(dates.DateFormat))
+	}
+	if err != nil {
+		log.Warn().Err(err).Str("cmd", "community").Str("symbol", symbol).Msg("cannot compute community growth")
+		service.sendMessage("community", ctx.EffectiveChat.Id, "🤷 No community data for *"+symbol+"*, only the watchlist and its peers are followed.")
+		return nil
+	}
+
+	msg := fmt.Sprintf("👥 *%s community on CMC*\n_%s_\n\n", symbol, community.Day)
+	msg += counterDetails("⭐ *Watchlist Count*", community.WatchCount)
+	msg += counterDetails("👥 *Followers*", community.Followers)
+	for _, signal := range community.Signals {
+		msg += "⚠️ " + signal + "\n"
+	}
+
+	service.sendMessage("community", ctx.EffectiveChat.Id, msg)
+	return nil
+}
+
+// counterDetails lists the value, growths and acceleration of a counter, when known.
+func counterDetails(title string, counter analytics.CounterGrowth) string {
+	if counter.Value <= 0 {
+		return ""
+	}
+
+	msg := fmt.Sprintf("%s: `%s`\n", title, humanize.Comma(counter.Value))
+	if counter.HasDaily {
+		msg += fmt.Sprintf("- 1 day: `%+.2f%%`", counter.Daily)
+		if counter.NormalRatio > 0 {
+			msg += fmt.Sprintf(" (%.1fx normal)", counter.NormalRatio)
+		}
+		msg += "\n"
+	}
+	if counter.HasWeekly {
+		msg += fmt.Sprintf("- 7 days: `%+.2f%%`\n", counter.Weekly)
+	}
+	if counter.HasMonthly {
+		msg += fmt.Sprintf("- 30 days: `%+.2f%%`\n", counter.Monthly)
+	}
+	if counter.HasAcceleration {
+		msg += fmt.Sprintf("- Acceleration vs last week: `%+.2f pts`\n", counter.Acceleration)
+	}
+	return msg + "\n"
+}
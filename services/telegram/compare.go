@@ -0,0 +1,41 @@
+package telegram
+
+import (
+	"strings"
+	"time"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/rs/zerolog/log"
+)
+
+// compareCmd compares a token with its competitors over the last week and month: /compare SYMBOL.
+func (service *Impl) compareCmd(b *gotgbot.Bot, ctx *ext.Context) error {
+	log.Info().Str("cmd", "compare").Str("username", ctx.EffectiveChat.Username).Int64("chatID", ctx.EffectiveChat.Id).Msg("command received")
+
+	if !service.isASubscriber(ctx.EffectiveChat.Id) {
+		msg := "⚠️ This feature is only available for subscribers !\n"
+		service.sendMessage("compare", ctx.EffectiveChat.Id, msg)
+		return nil
+	}
+
+	args := strings.Fields(ctx.Message.GetText())[1:]
+	if len(args) != 1 {
+		service.sendMessage("compare", ctx.EffectiveChat.Id, "Usage: `/compare <symbol>`")
+		return nil
+	}
+
+	symbol := strings.ToUpper(args[0])
+	yesterday := time.Now().AddDate(0, 0, -1)
+	msg := ""
+	for _, days := range compareWindows {
+		msg += service.reportService.PeerComparison(symbol, yesterday, days)
+	}
+	if msg == "" {
+		service.sendMessage("compare", ctx.EffectiveChat.Id, "🤷 No peers or not enough history to compare *"+symbol+"* (only TOP 1000).")
+		return nil
+	}
+
+	service.sendMessage("compare", ctx.EffectiveChat.Id, "📢 *Competitors* 🥊\n\n"+msg)
+	return nil
+}
@@ -0,0 +1,140 @@
+package telegram
+
+import (
+	"crypto-analytics/models/constants"
+	"crypto-analytics/models/entities"
+	"crypto-analytics/utils/dates"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/rs/zerolog/log"
+)
+
+const feedsUsage = "Usage: `/feeds [add <id> <url> [interval]|remove <id>|pause <id>|resume <id>|interval <id> <interval|default>]`"
+
+// feedsCmd lists the feed sources and lets the admin manage them:
+// /feeds add <id> <url> [interval]
+// /feeds remove|pause|resume <id>
+// /feeds interval <id> <interval|default>.
+func (service *Impl) feedsCmd(b *gotgbot.Bot, ctx *ext.Context) error {
+	log.Info().Str("cmd", "feeds").Str("username", ctx.EffectiveChat.Username).Int64("chatID", ctx.EffectiveChat.Id).Msg("command received")
+
+	if ctx.EffectiveChat.Id != constants.TelegramAdmin {
+		log.Warn().Str("cmd", "feeds").Int64("chatID", ctx.EffectiveChat.Id).Msg("forbidden usage")
+		return nil
+	}
+
+	args := strings.Fields(ctx.Message.GetText())[1:]
+	if len(args) == 0 {
+		service.sendMessage("feeds", ctx.EffectiveChat.Id, service.feedsMessage())
+		return nil
+	}
+
+	var msg string
+	switch strings.ToLower(args[0]) {
+	case "add":
+		msg = service.addFeedSource(args[1:])
+	case "remove":
+		msg = service.updateFeedSource(args[1:], "🗑 *%s* removed.", service.feedsService.RemoveSource)
+	case "pause":
+		msg = service.updateFeedSource(args[1:], "⏸ *%s* paused.", func(id string) error { return service.feedsService.PauseSource(id, true) })
+	case "resume":
+		msg = service.updateFeedSource(args[1:], "▶️ *%s* resumed.", func(id string) error { return service.feedsService.PauseSource(id, false) })
+	case "interval":
+		msg = service.setFeedSourceInterval(args[1:])
+	default:
+		msg = feedsUsage
+	}
+
+	service.sendMessage("feeds", ctx.EffectiveChat.Id, msg)
+	return nil
+}
+
+func (service *Impl) addFeedSource(args []string) string {
+	if len(args) < 2 || len(args) > 3 {
+		return "Usage: `/feeds add <id> <url> [interval]`"
+	}
+
+	var interval time.Duration
+	if len(args) == 3 {
+		parsed, err := time.ParseDuration(args[2])
+		if err != nil {
+			return "⚠️ The interval must be a duration, e.g. `30m` or `2h`."
+		}
+		interval = parsed
+	}
+
+	source, err := service.feedsService.AddSource(args[0], args[1], interval)
+	if err != nil {
+		log.Error().Err(err).Str("feedType", args[0]).Msg("cannot add feed source")
+		return "⚠️ Cannot add this source: " + escapeMarkdown(err.Error())
+	}
+	return fmt.Sprintf("✅ *%s* added (%s, every %s).", escapeMarkdown(source.FeedTypeID), source.Format, dates.FormatDuration(service.feedsService.GetInterval(source)))
+}
+
+func (service *Impl) updateFeedSource(args []string, done string, update func(id string) error) string {
+	if len(args) != 1 {
+		return feedsUsage
+	}
+
+	if err := update(args[0]); err != nil {
+		log.Error().Err(err).Str("feedType", args[0]).Msg("cannot update feed source")
+		return "⚠️ " + escapeMarkdown(err.Error())
+	}
+	return fmt.Sprintf(done, escapeMarkdown(strings.ToLower(args[0])))
+}
+
+func (service *Impl) setFeedSourceInterval(args []string) string {
+	if len(args) != 2 {
+		return "Usage: `/feeds interval <id> <interval|default>`"
+	}
+
+	var interval time.Duration
+	if !strings.EqualFold(args[1], "default") {
+		parsed, err := time.ParseDuration(args[1])
+		if err != nil {
+			return "⚠️ The interval must be a duration, e.g. `30m` or `2h`."
+		}
+		interval = parsed
+	}
+	return service.updateFeedSource(args[:1], "⏱ *%s* interval updated.", func(id string) error {
+		return service.feedsService.SetSourceInterval(id, interval)
+	})
+}
+
+func (service *Impl) feedsMessage() string {
+	sources, err := service.feedsService.GetSources()
+	if err != nil {
+		log.Error().Err(err).Str("cmd", "feeds").Msg("cannot fetch feed sources")
+		return "⚠️ Cannot read the feed sources."
+	}
+	if len(sources) == 0 {
+		return "📭 No feed source."
+	}
+
+	msg := "📡 *Feed sources*\n\n"
+	for _, source := range sources {
+		msg += feedSourceLine(source, service.feedsService.GetInterval(source))
+	}
+	return msg
+}
+
+func feedSourceLine(source entities.FeedSource, interval time.Duration) string {
+	state := "every " + dates.FormatDuration(interval)
+	if source.Paused {
+		state = "paused"
+	}
+	format := source.Format
+	if format == "" {
+		format = "unread"
+	}
+
+	msg := fmt.Sprintf("🔹 *%s* (%s, %s)\n%s\n", escapeMarkdown(source.FeedTypeID), format, state, escapeMarkdown(source.URL))
+	if !source.LastFetch.IsZero() {
+		msg += fmt.Sprintf("_Last read %s_\n", source.LastFetch.Format("2006-01-02 15:04"))
+	}
+	return msg + "\n"
+}
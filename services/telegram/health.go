@@ -0,0 +1,40 @@
+package telegram
+
+import (
+	"context"
+	"crypto-analytics/models/constants"
+	"crypto-analytics/utils/insights"
+	"encoding/json"
+	"net/http"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/spf13/viper"
+)
+
+// pollerBotClient beats every time updates are successfully polled from Telegram.
+type pollerBotClient struct {
+	gotgbot.BotClient
+	beat *insights.Heartbeat
+}
+
+func newPollerBotClient(beat *insights.Heartbeat) *pollerBotClient {
+	return &pollerBotClient{
+		BotClient: &gotgbot.BaseBotClient{Client: http.Client{}},
+		beat:      beat,
+	}
+}
+
+func (client *pollerBotClient) RequestWithContext(ctx context.Context, token string, method string, params map[string]string, data map[string]gotgbot.FileReader, opts *gotgbot.RequestOpts) (json.RawMessage, error) {
+	result, err := client.BotClient.RequestWithContext(ctx, token, method, params, data, opts)
+	if err == nil && method == "getUpdates" {
+		client.beat.Beat()
+	}
+	return result, err
+}
+
+// RegisterHealthChecks watches the poller, which only runs in production.
+func (service *Impl) RegisterHealthChecks(probes insights.Probes) {
+	if viper.GetBool(constants.Production) {
+		probes.AddCheck("telegram-poller", true, service.pollerBeat.Check(pollerMaxAge))
+	}
+}
@@ -0,0 +1,59 @@
+package telegram
+
+import (
+	"crypto-analytics/services/report"
+	"crypto-analytics/utils/dates"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/rs/zerolog/log"
+)
+
+// moversCmd sends the top gainers and losers: /movers [1d|7d|30d] [n].
+func (service *Impl) moversCmd(b *gotgbot.Bot, ctx *ext.Context) error {
+	log.Info().Str("cmd", "movers").Str("username", ctx.EffectiveChat.Username).Int64("chatID", ctx.EffectiveChat.Id).Msg("command received")
+
+	if !service.isASubscriber(ctx.EffectiveChat.Id) {
+		msg := "⚠️ This feature is only available for subscribers !\n"
+		service.sendMessage("movers", ctx.EffectiveChat.Id, msg)
+		return nil
+	}
+
+	usage := fmt.Sprintf("Usage: `/movers [1d|7d|30d] [1-%d]`", maxMoversCount)
+	label, count := defaultMoversWindow, defaultMoversCount
+	for _, arg := range strings.Fields(ctx.Message.GetText())[1:] {
+		if _, found := moversWindows[strings.ToLower(arg)]; found {
+			label = strings.ToLower(arg)
+		} else if n, err := strconv.Atoi(arg); err == nil && n > 0 && n <= maxMoversCount {
+			count = n
+		} else {
+			service.sendMessage("movers", ctx.EffectiveChat.Id, usage)
+			return nil
+		}
+	}
+
+	yesterday := time.Now().AddDate(0, 0, -1).Format(dates.DateFormat)
+	movers, err := service.cmcService.GetMovers(yesterday, moversWindows[label], count)
+	if err != nil {
+		log.Error().Err(err).Str("cmd", "movers").Msg("cannot compute movers")
+		service.sendMessage("movers", ctx.EffectiveChat.Id, "📉 Not enough price history for this window yet.")
+		return nil
+	}
+
+	msg := fmt.Sprintf("🎢 *Top Movers* over %s\n_%s → %s_\n\n", label, movers.Since, movers.Day)
+	msg += "🚀 *Gainers*\n"
+	for i, mover := range movers.Gainers {
+		msg += fmt.Sprintf("%d. %s `$%s` `%+.2f%%` (#%d)\n", i+1, mover.Symbol, report.FormatPrice(mover.Price), mover.PercentChange, mover.Rank)
+	}
+	msg += "\n🩸 *Losers*\n"
+	for i, mover := range movers.Losers {
+		msg += fmt.Sprintf("%d. %s `$%s` `%+.2f%%` (#%d)\n", i+1, mover.Symbol, report.FormatPrice(mover.Price), mover.PercentChange, mover.Rank)
+	}
+
+	service.sendMessage("movers", ctx.EffectiveChat.Id, msg)
+	return nil
+}
@@ -0,0 +1,126 @@
+package telegram
+
+import (
+	"crypto-analytics/models/entities"
+	"crypto-analytics/services/feeds"
+	"crypto-analytics/utils/dates"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/mmcdole/gofeed"
+	"github.com/rs/zerolog/log"
+)
+
+// Characters with a meaning in Telegram legacy Markdown.
+var markdownEscaper = strings.NewReplacer("_", "\\_", "*", "\\*", "`", "\\`", "[", "\\[")
+
+// sendNews sends a news item read from a feed source to the subscribers following one of the tokens it mentions.
+func (service *Impl) sendNews(source string, item *gofeed.Item, symbols []string) {
+	if item == nil || len(symbols) == 0 {
+		return
+	}
+
+	users, err := service.telegramRepo.FetchAll()
+	if err != nil {
+		log.Error().Err(err).Msg("Cannot fetch users for news")
+		return
+	}
+
+	mentioned := make(map[string]bool, len(symbols))
+	for _, symbol := range symbols {
+		mentioned[symbol] = true
+	}
+
+	msg := newsMessage(source, item, symbols)
+	for _, user := range users {
+		for _, token := range service.getUserTokens(user.ChatID) {
+			if mentioned[token.Symbol] {
+				service.sendMessage("news", user.ChatID, msg)
+				break
+			}
+		}
+	}
+}
+
+// newsCmd lists the latest articles mentioning a token: /news SYMBOL.
+func (service *Impl) newsCmd(b *gotgbot.Bot, ctx *ext.Context) error {
+	log.Info().Str("cmd", "news").Str("username", ctx.EffectiveChat.Username).Int64("chatID", ctx.EffectiveChat.Id).Msg("command received")
+
+	if !service.isASubscriber(ctx.EffectiveChat.Id) {
+		msg := "⚠️ This feature is only available for subscribers !\n"
+		service.sendMessage("news", ctx.EffectiveChat.Id, msg)
+		return nil
+	}
+
+	args := strings.Fields(ctx.Message.GetText())[1:]
+	if len(args) != 1 {
+		service.sendMessage("news", ctx.EffectiveChat.Id, "Usage: `/news <symbol>`")
+		return nil
+	}
+
+	symbol := strings.ToUpper(args[0])
+	items, err := service.feedsService.FetchNews(symbol, newsListCount)
+	if err != nil {
+		log.Error().Err(err).Str("cmd", "news").Str("symbol", symbol).Msg("cannot fetch news")
+	}
+	if len(items) == 0 {
+		service.sendMessage("news", ctx.EffectiveChat.Id, "🤷 No recent article mentioning *"+symbol+"*.")
+		return nil
+	}
+
+	msg := fmt.Sprintf("📰 *News mentioning %s*\n\n", symbol) + newsList(items)
+	service.sendMessage("news", ctx.EffectiveChat.Id, msg)
+	return nil
+}
+
+// newsList formats stored articles, one per paragraph with their day, source and link.
+func newsList(items []entities.NewsItem) string {
+	msg := ""
+	for _, item := range items {
+		msg += fmt.Sprintf("🔹 *%s* - %s\n_%s_\n", item.PublishedAt.Format(dates.DateFormat), escapeMarkdown(item.Title), escapeMarkdown(item.Source))
+		if item.Link != "" {
+			msg += "🔗 " + escapeMarkdown(item.Link) + "\n"
+		}
+		msg += "\n"
+	}
+	return msg
+}
+
+func newsMessage(source string, item *gofeed.Item, symbols []string) string {
+	msg := "📰 *" + escapeMarkdown(strings.TrimSpace(item.Title)) + "*\n"
+	if source != "" {
+		msg += "_" + escapeMarkdown(source) + "_\n"
+	}
+	if summary := newsSummary(item); summary != "" {
+		msg += "\n" + escapeMarkdown(summary) + "\n"
+	}
+	msg += "\n🏷 " + strings.Join(symbols, ", ")
+	if item.Link != "" {
+		msg += "\n🔗 " + escapeMarkdown(item.Link)
+	}
+	return msg
+}
+
+// newsSummary turns the HTML description of an item, or its content, into a short plain text.
+func newsSummary(item *gofeed.Item) string {
+	text := feeds.PlainText(item.Description)
+	if text == "" {
+		text = feeds.PlainText(item.Content)
+	}
+	if utf8.RuneCountInString(text) <= newsSummaryLength {
+		return text
+	}
+
+	runes := []rune(text)[:newsSummaryLength]
+	if cut := strings.LastIndex(string(runes), " "); cut > 0 {
+		return string(runes)[:cut] + "…"
+	}
+	return string(runes) + "…"
+}
+
+func escapeMarkdown(text string) string {
+	return markdownEscaper.Replace(text)
+}
@@ -0,0 +1,143 @@
+package telegram
+
+import (
+	"crypto-analytics/services/analytics"
+	"crypto-analytics/utils/dates"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/patrickmn/go-cache"
+	"github.com/rs/zerolog/log"
+)
+
+// ranksCmd sends the biggest rank climbers and fallers: /ranks [1d|7d|30d].
+func (service *Impl) ranksCmd(b *gotgbot.Bot, ctx *ext.Context) error {
+	log.Info().Str("cmd", "ranks").Str("username", ctx.EffectiveChat.Username).Int64("chatID", ctx.EffectiveChat.Id).Msg("command received")
+
+	if !service.isASubscriber(ctx.EffectiveChat.Id) {
+		msg := "⚠️ This feature is only available for subscribers !\n"
+		service.sendMessage("ranks", ctx.EffectiveChat.Id, msg)
+		return nil
+	}
+
+	label := defaultRanksWindow
+	if args := strings.Fields(ctx.Message.GetText())[1:]; len(args) > 0 {
+		label = strings.ToLower(args[0])
+	}
+	days, found := ranksWindows[label]
+	if !found {
+		service.sendMessage("ranks", ctx.EffectiveChat.Id, "Usage: `/ranks [1d|7d|30d]`")
+		return nil
+	}
+
+	yesterday := time.Now().AddDate(0, 0, -1).Format(dates.DateFormat)
+	movement, err := service.analyticsService.ComputeRankMovement(yesterday, days, rankMoversCount)
+	if err != nil {
+		log.Error().Err(err).Str("cmd", "ranks").Msg("cannot compute rank movement")
+		service.sendMessage("ranks", ctx.EffectiveChat.Id, "📉 Not enough ranking history for this window yet.")
+		return nil
+	}
+
+	service.sendMessage("ranks", ctx.EffectiveChat.Id, rankMovementMessage(fmt.Sprintf("🏆 *Rank Movers* over %s", label), movement))
+	return nil
+}
+
+func (service *Impl) sendWeeklyRankDigest() {
+	log.Info().Msg("Send weekly rank digest")
+	users, err := service.telegramRepo.FetchAll()
+	if err != nil || len(users) == 0 {
+		return
+	}
+
+	yesterday := time.Now().AddDate(0, 0, -1).Format(dates.DateFormat)
+	movement, err := service.analyticsService.ComputeRankMovement(yesterday, 7, rankMoversCount)
+	if err != nil {
+		log.Error().Err(err).Str("cmd", "rank_digest").Msg("cannot compute rank movement")
+		return
+	}
+
+	message := rankMovementMessage("📅 *Weekly Rank Digest*", movement)
+	for _, user := range users {
+		service.sendMessage("rank_digest", user.ChatID, message)
+	}
+}
+
+// sendRankAlerts tells every user which of their tokens entered or left a top N yesterday, once per day.
+func (service *Impl) sendRankAlerts() {
+	yesterday := time.Now().AddDate(0, 0, -1).Format(dates.DateFormat)
+	cacheKey := "rank_alerts_" + yesterday
+	if _, sent := service.cache.Get(cacheKey); sent {
+		return
+	}
+
+	movement, err := service.analyticsService.ComputeRankMovement(yesterday, 1, 0)
+	if err != nil {
+		log.Warn().Err(err).Msg("Cannot compute rank crossings")
+		return
+	}
+	service.cache.Set(cacheKey, true, cache.DefaultExpiration)
+	if len(movement.Crossings) == 0 {
+		return
+	}
+
+	users, err := service.telegramRepo.FetchAll()
+	if err != nil {
+		log.Error().Err(err).Msg("Cannot fetch users for rank alerts")
+		return
+	}
+
+	for _, user := range users {
+		watched := make(map[string]bool)
+		for _, token := range service.getUserTokens(user.ChatID) {
+			watched[token.Symbol] = true
+		}
+
+		msg := ""
+		for _, crossing := range movement.Crossings {
+			if watched[crossing.Symbol] {
+				msg += crossingLine(crossing)
+			}
+		}
+		if msg != "" {
+			service.sendMessage("rank_alert", user.ChatID, "🚨 *Rank Alert*\n\n"+msg)
+		}
+	}
+}
+
+func rankMovementMessage(title string, movement analytics.RankMovement) string {
+	msg := fmt.Sprintf("%s\n_%s → %s, top 1000_\n\n", title, movement.Since, movement.Day)
+
+	msg += "🚀 *Biggest climbers*\n"
+	for _, change := range movement.Climbers {
+		msg += fmt.Sprintf("- %s: `#%d` → `#%d` (+%d)\n", change.Symbol, change.PreviousRank, change.Rank, change.Delta())
+	}
+	msg += "\n🪂 *Biggest fallers*\n"
+	for _, change := range movement.Fallers {
+		msg += fmt.Sprintf("- %s: `#%d` → `#%d` (%d)\n", change.Symbol, change.PreviousRank, change.Rank, change.Delta())
+	}
+
+	if len(movement.Crossings) > 0 {
+		msg += "\n🚧 *Top thresholds*\n"
+		for _, crossing := range movement.Crossings {
+			msg += crossingLine(crossing)
+		}
+	}
+	return msg
+}
+
+func crossingLine(crossing analytics.ThresholdCrossing) string {
+	if crossing.Entered {
+		return fmt.Sprintf("✅ %s entered the top %d: %s → `#%d`\n", crossing.Symbol, crossing.Threshold, formatRank(crossing.PreviousRank), crossing.Rank)
+	}
+	return fmt.Sprintf("❌ %s left the top %d: `#%d` → %s\n", crossing.Symbol, crossing.Threshold, crossing.PreviousRank, formatRank(crossing.Rank))
+}
+
+func formatRank(rank int) string {
+	if rank == 0 {
+		return "`>1000`"
+	}
+	return fmt.Sprintf("`#%d`", rank)
+}
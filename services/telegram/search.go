@@ -0,0 +1,39 @@
+package telegram
+
+import (
+	"strings"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/rs/zerolog/log"
+)
+
+// searchCmd lists the recent articles most relevant to a query: /search QUERY.
+func (service *Impl) searchCmd(b *gotgbot.Bot, ctx *ext.Context) error {
+	log.Info().Str("cmd", "search").Str("username", ctx.EffectiveChat.Username).Int64("chatID", ctx.EffectiveChat.Id).Msg("command received")
+
+	if !service.isASubscriber(ctx.EffectiveChat.Id) {
+		msg := "⚠️ This feature is only available for subscribers !\n"
+		service.sendMessage("search", ctx.EffectiveChat.Id, msg)
+		return nil
+	}
+
+	args := strings.Fields(ctx.Message.GetText())[1:]
+	if len(args) == 0 {
+		service.sendMessage("search", ctx.EffectiveChat.Id, "Usage: `/search <query>`")
+		return nil
+	}
+
+	query := strings.Join(args, " ")
+	items, err := service.feedsService.Search(query, newsListCount)
+	if err != nil {
+		log.Error().Err(err).Str("cmd", "search").Str("query", query).Msg("cannot search news")
+	}
+	if len(items) == 0 {
+		service.sendMessage("search", ctx.EffectiveChat.Id, "🤷 No recent article matching *"+escapeMarkdown(query)+"*.")
+		return nil
+	}
+
+	service.sendMessage("search", ctx.EffectiveChat.Id, "🔎 *News matching "+escapeMarkdown(query)+"*\n\n"+newsList(items))
+	return nil
+}
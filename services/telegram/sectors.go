@@ -0,0 +1,58 @@
+package telegram
+
+import (
+	"crypto-analytics/utils/dates"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/dustin/go-humanize"
+	"github.com/rs/zerolog/log"
+)
+
+// sectorsCmd sends the aggregates of each sector: /sectors [1d|7d|30d].
+func (service *Impl) sectorsCmd(b *gotgbot.Bot, ctx *ext.Context) error {
+	log.Info().Str("cmd", "sectors").Str("username", ctx.EffectiveChat.Username).Int64("chatID", ctx.EffectiveChat.Id).Msg("command received")
+
+	if !service.isASubscriber(ctx.EffectiveChat.Id) {
+		msg := "⚠️ This feature is only available for subscribers !\n"
+		service.sendMessage("sectors", ctx.EffectiveChat.Id, msg)
+		return nil
+	}
+
+	label := defaultSectorsWindow
+	if args := strings.Fields(ctx.Message.GetText())[1:]; len(args) > 0 {
+		label = strings.ToLower(args[0])
+	}
+	days, found := sectorsWindows[label]
+	if !found {
+		service.sendMessage("sectors", ctx.EffectiveChat.Id, "Usage: `/sectors [1d|7d|30d]`")
+		return nil
+	}
+
+	yesterday := time.Now().AddDate(0, 0, -1).Format(dates.DateFormat)
+	performances, err := service.analyticsService.ComputeSectorPerformance(yesterday, days)
+	if err != nil || len(performances) == 0 {
+		log.Error().Err(err).Str("cmd", "sectors").Msg("cannot compute sector performance")
+		service.sendMessage("sectors", ctx.EffectiveChat.Id, "📉 No sector data available yet.")
+		return nil
+	}
+
+	msg := fmt.Sprintf("🧩 *Sectors* over %s\n_%s → %s, top 1000_\n\n", label, performances[0].Since, performances[0].Day)
+	for _, performance := range performances {
+		leaders := make([]string, 0, sectorLeadersCount)
+		for _, member := range performance.Members[:min(sectorLeadersCount, len(performance.Members))] {
+			leaders = append(leaders, member.Symbol)
+		}
+
+		msg += fmt.Sprintf("🔹 *%s* (%d tokens, %d trending)\n", performance.Name, len(performance.Members), performance.Trending)
+		msg += fmt.Sprintf("🏛 Market Cap: `$%s` (`%+.2f%%`)\n", humanize.SIWithDigits(performance.TotalMarketcap, 2, ""), performance.MarketcapChangePercent)
+		msg += fmt.Sprintf("📊 Median return: `%+.2f%%`\n", performance.MedianReturn)
+		msg += fmt.Sprintf("👑 Leaders: %s\n\n", strings.Join(leaders, ", "))
+	}
+
+	service.sendMessage("sectors", ctx.EffectiveChat.Id, msg)
+	return nil
+}
@@ -0,0 +1,104 @@
+package telegram
+
+import (
+	"bytes"
+	"crypto-analytics/pkg/chart"
+	"crypto-analytics/utils/dates"
+	"fmt"
+	"image/color"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/rs/zerolog/log"
+)
+
+// sentimentCmd sends the chart of the stored Fear & Greed index: /sentiment [7d|30d|90d|1y].
+func (service *Impl) sentimentCmd(b *gotgbot.Bot, ctx *ext.Context) error {
+	log.Info().Str("cmd", "sentiment").Str("username", ctx.EffectiveChat.Username).Int64("chatID", ctx.EffectiveChat.Id).Msg("command received")
+
+	if !service.isASubscriber(ctx.EffectiveChat.Id) {
+		msg := "⚠️ This feature is only available for subscribers !\n"
+		service.sendMessage("sentiment", ctx.EffectiveChat.Id, msg)
+		return nil
+	}
+
+	label := defaultSentimentWindow
+	if args := strings.Fields(ctx.Message.GetText())[1:]; len(args) > 0 {
+		label = strings.ToLower(args[0])
+	}
+	days, found := sentimentWindows[label]
+	if !found {
+		service.sendMessage("sentiment", ctx.EffectiveChat.Id, "Usage: `/sentiment [7d|30d|90d|1y]`")
+		return nil
+	}
+
+	to := time.Now()
+	from := to.AddDate(0, 0, -days)
+	indicators, err := service.cryptorankService.GetDailyMarketIndicators(from.Format(dates.DateFormat), to.Format(dates.DateFormat))
+	if err != nil {
+		log.Error().Err(err).Str("cmd", "sentiment").Msg("cannot fetch market indicators")
+		service.sendMessage("sentiment", ctx.EffectiveChat.Id, getGenericErrorMEssage())
+		return nil
+	}
+
+	points := make([]chart.Point, 0, len(indicators))
+	for _, indicator := range indicators {
+		day, errDay := time.ParseInLocation(dates.DateFormat, indicator.Day, time.Local)
+		if errDay != nil || indicator.FearGreedIndex == 0 {
+			continue
+		}
+		points = append(points, chart.Point{Time: day, Value: float64(indicator.FearGreedIndex)})
+	}
+
+	var image bytes.Buffer
+	errChart := chart.LineChart{
+		Title:       fmt.Sprintf("Fear & Greed Index - last %s", label),
+		Series:      []chart.Series{{Name: "Fear & Greed", Color: chart.Blue, Points: points}},
+		Bands:       fearGreedBands(),
+		YMin:        0,
+		YMax:        100,
+		FormatValue: func(value float64) string { return strconv.Itoa(int(value)) },
+	}.Render(&image)
+	if errChart != nil {
+		service.sendMessage("sentiment", ctx.EffectiveChat.Id, "📉 Not enough sentiment history yet, come back in a few days!")
+		return nil
+	}
+
+	last := points[len(points)-1]
+	emoji, sentiment := getSentiment(int(last.Value))
+	caption := fmt.Sprintf("🧭 *Fear & Greed Index* over the last %s\nNow: %s `%d/100` (%s)", label, emoji, int(last.Value), sentiment)
+	service.sendPhoto("sentiment", ctx.EffectiveChat.Id, "sentiment.png", &image, caption)
+	return nil
+}
+
+// fearGreedComparison describes the move of the index since a previous value, empty when unknown.
+func fearGreedComparison(label string, today int, previous int) string {
+	if previous <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s vs %s: `%d` (%+d)\n", trendArrow(float64(today-previous)), label, previous, today-previous)
+}
+
+func trendArrow(delta float64) string {
+	switch {
+	case delta > 0:
+		return "↗️"
+	case delta < 0:
+		return "↘️"
+	default:
+		return "➡️"
+	}
+}
+
+func fearGreedBands() []chart.Band {
+	return []chart.Band{
+		{From: 0, To: 20, Color: color.NRGBA{R: 0xd6, G: 0x27, B: 0x28, A: 0x30}},
+		{From: 20, To: 40, Color: color.NRGBA{R: 0xff, G: 0x7f, B: 0x0e, A: 0x30}},
+		{From: 40, To: 60, Color: color.NRGBA{R: 0xff, G: 0xd7, B: 0x00, A: 0x30}},
+		{From: 60, To: 80, Color: color.NRGBA{R: 0x8b, G: 0xc3, B: 0x4a, A: 0x30}},
+		{From: 80, To: 100, Color: color.NRGBA{R: 0x2c, G: 0xa0, B: 0x2c, A: 0x30}},
+	}
+}
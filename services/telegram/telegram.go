@@ -1,19 +1,27 @@
 package telegram
 
 import (
+	"bytes"
 	"crypto-analytics/models/constants"
 	"crypto-analytics/models/entities"
 	"crypto-analytics/pkg/observer"
 	telegramRepo "crypto-analytics/repositories/telegram"
-	"math"
-	"strconv"
+	userTokensRepo "crypto-analytics/repositories/usertokens"
+	"crypto-analytics/services/alerts"
+	"crypto-analytics/services/analytics"
 
 	//geckoService "crypto-analytics/services/coingecko"
 	cmcService "crypto-analytics/services/coinmarketcap"
 	"crypto-analytics/services/cryptorank"
+	"crypto-analytics/services/feeds"
+	"crypto-analytics/services/report"
 	twitterService "crypto-analytics/services/twitter"
+	"crypto-analytics/services/watchlist"
 	"crypto-analytics/utils/dates"
+	"crypto-analytics/utils/insights"
+	"crypto-analytics/utils/metrics"
 	"fmt"
+	"io"
 	"strings"
 	"time"
 
@@ -24,15 +32,17 @@ import (
 	"github.com/go-co-op/gocron/v2"
 	"github.com/patrickmn/go-cache"
 	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
 )
 
-func New(scheduler gocron.Scheduler, token string, telegramRepo telegramRepo.Repository, cmcService cmcService.Service, twitterService twitterService.Service, cryptorankService cryptorank.Service) (*Impl, error) {
+func New(scheduler gocron.Scheduler, token string, telegramRepo telegramRepo.Repository, userTokensRepo userTokensRepo.Repository, cmcService cmcService.Service, twitterService twitterService.Service, cryptorankService cryptorank.Service, watchlistService watchlist.Service, alertsService alerts.Service, reportService report.Service, analyticsService analytics.Service, feedsService feeds.Service) (*Impl, error) {
 
 	if token == "" {
 		return &Impl{}, ErrTokenIsMissing
 	}
 
-	b, err := gotgbot.NewBot(token, nil)
+	pollerBeat := &insights.Heartbeat{}
+	b, err := gotgbot.NewBot(token, &gotgbot.BotOpts{BotClient: newPollerBotClient(pollerBeat)})
 	if err != nil {
 		return &Impl{}, ErrBotNotInitialized
 	}
@@ -48,9 +58,16 @@ func New(scheduler gocron.Scheduler, token string, telegramRepo telegramRepo.Rep
 	service := Impl{
 		bot:               b,
 		telegramRepo:      telegramRepo,
+		userTokensRepo:    userTokensRepo,
 		cmcService:        cmcService,
 		twitterService:    twitterService,
 		cryptorankService: cryptorankService,
+		watchlistService:  watchlistService,
+		alertsService:     alertsService,
+		reportService:     reportService,
+		analyticsService:  analyticsService,
+		feedsService:      feedsService,
+		pollerBeat:        pollerBeat,
 		cache:             cache.New(1*time.Hour, 2*time.Hour)}
 
 	dispatcher.AddHandler(handlers.NewCommand("start", service.startCmd))
@@ -61,8 +78,21 @@ func New(scheduler gocron.Scheduler, token string, telegramRepo telegramRepo.Rep
 	dispatcher.AddHandler(handlers.NewCommand("maintenance", service.maintenanceCmd))
 	dispatcher.AddHandler(handlers.NewCommand("banner", service.adminMessageCmd))
 	dispatcher.AddHandler(handlers.NewCommand("refresh", service.refreshTrendingMessageCmd))
+	dispatcher.AddHandler(handlers.NewCommand("watchlist", service.watchlistCmd))
 
 	dispatcher.AddHandler(handlers.NewCommand("tokens", service.tokenInfoCmd))
+	dispatcher.AddHandler(handlers.NewCommand("watch", service.watchCmd))
+	dispatcher.AddHandler(handlers.NewCommand("alert", service.alertCmd))
+	dispatcher.AddHandler(handlers.NewCommand("sentiment", service.sentimentCmd))
+	dispatcher.AddHandler(handlers.NewCommand("chart", service.chartCmd))
+	dispatcher.AddHandler(handlers.NewCommand("ranks", service.ranksCmd))
+	dispatcher.AddHandler(handlers.NewCommand("movers", service.moversCmd))
+	dispatcher.AddHandler(handlers.NewCommand("sectors", service.sectorsCmd))
+	dispatcher.AddHandler(handlers.NewCommand("compare", service.compareCmd))
+	dispatcher.AddHandler(handlers.NewCommand("community", service.communityCmd))
+	dispatcher.AddHandler(handlers.NewCommand("news", service.newsCmd))
+	dispatcher.AddHandler(handlers.NewCommand("search", service.searchCmd))
+	dispatcher.AddHandler(handlers.NewCommand("feeds", service.feedsCmd))
 	dispatcher.AddHandler(handlers.NewCommand("", service.unknownCmd))
 	service.updater = ext.NewUpdater(dispatcher, nil)
 
@@ -93,10 +123,19 @@ func New(scheduler gocron.Scheduler, token string, telegramRepo telegramRepo.Rep
 		return nil, errAIndicatorJob
 	}
 
+	_, errRankDigestJob := scheduler.NewJob(
+		gocron.CronJob(viper.GetString(constants.RankDigestCronTab), true),
+		gocron.NewTask(func() { service.sendWeeklyRankDigest() }),
+		gocron.WithName("Send weekly rank digest"),
+	)
+	if errRankDigestJob != nil {
+		return nil, errRankDigestJob
+	}
+
 	/**
 		_, errJobGenerateReport := scheduler.NewJob(
 			gocron.CronJob("/2 * * * *", true),
-			gocron.NewTask(func() { service.generateReport() }),
+			gocron.NewTask(func() { service.reportService.Generate() }),
 			gocron.WithName("Generate daily report"),
 		)
 		if errJobGenerateReport != nil {
@@ -112,7 +151,7 @@ func New(scheduler gocron.Scheduler, token string, telegramRepo telegramRepo.Rep
 			return nil, errJobNotify
 		}
 	**/
-	service.generateReport()
+	service.reportService.Generate()
 	service.sendDailyReport(constants.TelegramAdmin)
 	service.sendDailyIndicator(constants.TelegramAdmin)
 	return &service, nil
@@ -165,7 +204,7 @@ func (service *Impl) adminMessageCmd(b *gotgbot.Bot, ctx *ext.Context) error {
 	if err == nil {
 		for _, user := range users {
 			log.Info().Str("cmd", "admin_message").Int64("chatID", user.ChatID).Msg("send global message")
-			service.bot.SendMessage(user.ChatID, msg, &gotgbot.SendMessageOpts{ParseMode: "Markdown"})
+			service.sendMessage("banner", user.ChatID, msg)
 		}
 	}
 
@@ -176,7 +215,7 @@ func (service *Impl) tokenInfoCmd(b *gotgbot.Bot, ctx *ext.Context) error {
 
 	if !service.isASubscriber(ctx.EffectiveChat.Id) {
 		msg := "⚠️ This feature is only available for subscribers !\n"
-		service.bot.SendMessage(ctx.EffectiveChat.Id, msg, &gotgbot.SendMessageOpts{ParseMode: "Markdown"})
+		service.sendMessage("tokens", ctx.EffectiveChat.Id, msg)
 	}
 
 	tokensAsString := strings.Join(strings.Fields(ctx.Message.GetText())[1:], " ")
@@ -198,6 +237,7 @@ func (service *Impl) tokenInfoCmd(b *gotgbot.Bot, ctx *ext.Context) error {
 
 				ok = true
 				msg += fmt.Sprintf("💰 Price: `$%.2f`\n", histo.Price)
+				msg += service.reportService.LiveQuoteLine(histo.Symbol)
 				if errPrice7Days == nil {
 					percent := ((histo.Price - histo7DaysAgo.Price) / histo7DaysAgo.Price) * 100
 					if percent < 0 {
@@ -210,6 +250,8 @@ func (service *Impl) tokenInfoCmd(b *gotgbot.Bot, ctx *ext.Context) error {
 				msg += fmt.Sprintf("📊 Rank: `#%d`\n", histo.Rank)
 				//msg += fmt.Sprintf("🏛 Market Cap: `$%.2f`\n", histo.Marketcap)
 				msg += fmt.Sprintf("🏛 Market Cap: `$%s`\n", humanize.CommafWithDigits(histo.Marketcap, 2))
+				msg += service.reportService.TechnicalLines(histo.Symbol, time.Now().AddDate(0, 0, -1))
+				msg += service.reportService.SectorLines(histo.Symbol, time.Now().AddDate(0, 0, -1))
 
 				if trendy {
 					msg += fmt.Sprintf("🔥 Trending: *%s*\n\n", "Yes! 🚀")
@@ -240,7 +282,8 @@ func (service *Impl) tokenInfoCmd(b *gotgbot.Bot, ctx *ext.Context) error {
 			msg += "\n"
 			msg += "📆 Data from *yesterday*. Stay tuned for more updates! 📈\n\n"
 			msg += "⚠️ The report is based on yesterday's data, so 7-day data actually means today minus 8 days.\n"
-			service.bot.SendMessage(ctx.EffectiveChat.Id, msg, &gotgbot.SendMessageOpts{ParseMode: "Markdown"})
+			msg += "⚡ Live prices are refreshed every few minutes.\n"
+			service.sendMessage("tokens", ctx.EffectiveChat.Id, msg)
 		}
 	}
 	return nil
@@ -264,7 +307,7 @@ func (service *Impl) maintenanceCmd(b *gotgbot.Bot, ctx *ext.Context) error {
 	if err == nil {
 		for _, user := range users {
 			log.Info().Str("cmd", "maintenance").Int64("chatID", user.ChatID).Msg("send maintenance")
-			service.bot.SendMessage(user.ChatID, msg, &gotgbot.SendMessageOpts{ParseMode: "Markdown"})
+			service.sendMessage("maintenance", user.ChatID, msg)
 		}
 	}
 
@@ -274,19 +317,19 @@ func (service *Impl) maintenanceCmd(b *gotgbot.Bot, ctx *ext.Context) error {
 
 func (service *Impl) startCmd(b *gotgbot.Bot, ctx *ext.Context) error {
 	log.Info().Str("cmd", "start").Str("username", ctx.EffectiveChat.Username).Int64("chatID", ctx.EffectiveChat.Id).Msg("command received")
-	service.bot.SendMessage(ctx.EffectiveChat.Id, getMessageFromMessageType(MessageTypeWelcome), &gotgbot.SendMessageOpts{ParseMode: "Markdown"})
+	service.sendMessage("start", ctx.EffectiveChat.Id, getMessageFromMessageType(MessageTypeWelcome))
 	return nil
 }
 
 func (service *Impl) helpCmd(b *gotgbot.Bot, ctx *ext.Context) error {
 	log.Info().Str("cmd", "help").Str("username", ctx.EffectiveChat.Username).Int64("chatID", ctx.EffectiveChat.Id).Msg("command received")
-	service.bot.SendMessage(ctx.EffectiveChat.Id, getMessageFromMessageType(MessageTypeHelp), &gotgbot.SendMessageOpts{ParseMode: "Markdown"})
+	service.sendMessage("help", ctx.EffectiveChat.Id, getMessageFromMessageType(MessageTypeHelp))
 	return nil
 }
 
 func (service *Impl) unknownCmd(b *gotgbot.Bot, ctx *ext.Context) error {
 	log.Info().Str("cmd", "unknown").Str("username", ctx.EffectiveChat.Username).Int64("chatID", ctx.EffectiveChat.Id).Msg("command received")
-	service.bot.SendMessage(ctx.EffectiveChat.Id, getGenericErrorMEssage(), &gotgbot.SendMessageOpts{ParseMode: "Markdown"})
+	service.sendMessage("unknown", ctx.EffectiveChat.Id, getGenericErrorMEssage())
 	return nil
 }
 
@@ -298,7 +341,7 @@ func (service *Impl) subscribeCmd(b *gotgbot.Bot, ctx *ext.Context) error {
 	} else {
 		service.notifyAdminOnNewUser(ctx.EffectiveChat.Id)
 	}
-	service.bot.SendMessage(ctx.EffectiveChat.Id, getMessageFromMessageType(MessageTypeSubscribe), &gotgbot.SendMessageOpts{ParseMode: "Markdown"})
+	service.sendMessage("subscribe", ctx.EffectiveChat.Id, getMessageFromMessageType(MessageTypeSubscribe))
 
 	return nil
 }
@@ -309,7 +352,13 @@ func (service *Impl) unsubscribeCmd(b *gotgbot.Bot, ctx *ext.Context) error {
 	if err != nil {
 		log.Error().Err(err).Int64("chatID", ctx.EffectiveChat.Id).Msg("error on deleted")
 	}
-	service.bot.SendMessage(ctx.EffectiveChat.Id, getMessageFromMessageType(MessageTypeUnsubscribe), &gotgbot.SendMessageOpts{ParseMode: "Markdown"})
+	if errTokens := service.userTokensRepo.DeleteAllForUser(ctx.EffectiveChat.Id); errTokens != nil {
+		log.Error().Err(errTokens).Int64("chatID", ctx.EffectiveChat.Id).Msg("error on deleted")
+	}
+	if errAlerts := service.alertsService.DeleteAllForUser(ctx.EffectiveChat.Id); errAlerts != nil {
+		log.Error().Err(errAlerts).Int64("chatID", ctx.EffectiveChat.Id).Msg("error on deleted")
+	}
+	service.sendMessage("unsubscribe", ctx.EffectiveChat.Id, getMessageFromMessageType(MessageTypeUnsubscribe))
 	return nil
 }
 
@@ -321,7 +370,7 @@ func (service *Impl) notifyAdminOnNewUser(chatID int64) {
 		msg += fmt.Sprintf("📅 *Date:* `%s`\n", time.Now().Format("2006-01-02 15:04:05"))
 		msg += "\nLe bot gagne en popularité ! 📈🔥"
 
-		service.bot.SendMessage(constants.TelegramAdmin, msg, &gotgbot.SendMessageOpts{ParseMode: "Markdown"})
+		service.sendMessage("new_user_notification", constants.TelegramAdmin, msg)
 	}
 }
 
@@ -331,7 +380,7 @@ func (service *Impl) dailyAdminReport() {
 		msg := "📢 *Rapport quotidien des abonnés* 📊\n\n"
 		msg += fmt.Sprintf("👥 *Nombre total d'abonnés:* `%d`\n", len(users))
 
-		service.bot.SendMessage(constants.TelegramAdmin, msg, &gotgbot.SendMessageOpts{ParseMode: "Markdown"})
+		service.sendMessage("admin_report", constants.TelegramAdmin, msg)
 	}
 }
 
@@ -343,7 +392,7 @@ func (service *Impl) reportCmd(b *gotgbot.Bot, ctx *ext.Context) error {
 
 func (service *Impl) tendringNotify() {
 	log.Info().Msg("Check trending notification")
-	cryptocurrencies := constants.GetCrytoWatch()
+	cryptocurrencies := service.watchlistService.GetWatchlist()
 	users, _ := service.telegramRepo.FetchAll()
 	if len(users) > 0 {
 		today := time.Now().Format(dates.DateFormat)
@@ -362,7 +411,7 @@ func (service *Impl) tendringNotify() {
 						msg += "\n⚡ Stay ahead of the market!\n"
 						for _, user := range users {
 							log.Info().Int64("chatID", user.ChatID).Msg("send trending notification")
-							service.bot.SendMessage(user.ChatID, msg, &gotgbot.SendMessageOpts{ParseMode: "Markdown"})
+							service.sendMessage("trending_notification", user.ChatID, msg)
 						}
 					}
 				}
@@ -371,99 +420,22 @@ func (service *Impl) tendringNotify() {
 	}
 }
 
-func (service *Impl) generateReport() {
-	log.Info().Msg("Generate daily report")
-	cryptocurrencies := constants.GetCrytoWatch()
-	ok := false
-	if len(cryptocurrencies) > 0 {
-		msg := "📢 *Daily Crypto Report* 🚀\n\n"
-
-		msg += "📈 *Maket Overview this last 2 days*\n"
-
-		yesterdayBTC, err := service.cmcService.FetchForSymbolYesterday("BTC")
-		twoDaysBTC, err2 := service.cmcService.FetchForSymbolForTwoDaysAgo("BTC")
-		if err == nil && err2 == nil {
-			msg += GenerateTokenSentence("BTC", yesterdayBTC.Price, twoDaysBTC.Price) + "\n" //fmt.Sprintf("💰 BTC Price: `$%.2f`\n", histo.Price)
-		}
-		yesterdayETH, err := service.cmcService.FetchForSymbolYesterday("ETH")
-		twoDaysETH, err2 := service.cmcService.FetchForSymbolForTwoDaysAgo("ETH")
-		if err == nil && err2 == nil {
-			msg += GenerateTokenSentence("ETH", yesterdayETH.Price, twoDaysETH.Price) + "\n\n" //fmt.Sprintf("💰 BTC Price: `$%.2f`\n", histo.Price)
-		}
-		/**
-		topGainers, err := service.cmcService.GetTopGainers()
-		if err == nil {
-			for _, gainer := range topGainers {
-				msg += fmt.Sprintf("- %s (+%.2f%%)\n", gainer.Symbol, gainer.PercentChange)
-			}
-		} else {
-			log.Error().Err(err).Msg("error on top gainers")
-		}
-			**/
-
-		msg += "\n"
-		msg += "👉 *Focus on tokens*\n\n"
-
-		for _, crycryptocurrency := range cryptocurrencies {
-			msg += "🔹 *" + crycryptocurrency.Desc + "*\n"
-			histo, errPrice := service.cmcService.FetchForSymbolYesterday(crycryptocurrency.Symbol)
-			histo7DaysAgo, errPrice7Days := service.cmcService.FetchForSymbol7DaysAgo(crycryptocurrency.Symbol)
-			trendy := service.cmcService.IsCryptoTrendyYersterday(crycryptocurrency.Symbol)
-			community, errCommunity := service.cmcService.FetchCommunityDataForSymbolYesterday(crycryptocurrency.CryptoId)
-
-			if errPrice == nil {
-
-				msg += fmt.Sprintf("💰 Price: `$%.2f`\n", histo.Price)
-				if errPrice7Days == nil {
-					percent := ((histo.Price - histo7DaysAgo.Price) / histo7DaysAgo.Price) * 100
-					if percent < 0 {
-						msg += fmt.Sprintf("📉 7 days : `%.2f%%`\n", percent)
-					} else {
-						msg += fmt.Sprintf("📈 7 days : `%.2f%%`\n", percent)
-					}
-
-				}
-				msg += fmt.Sprintf("📊 Rank: `#%d`\n", histo.Rank)
-				msg += fmt.Sprintf("🏛 Market Cap: `$%s`\n", humanize.CommafWithDigits(histo.Marketcap, 2))
-				//fmt.Sprintf("🏛 Market Cap: `$%.2f`\n", histo.Marketcap)
-				ok = true
-			}
-			if trendy {
-				msg += fmt.Sprintf("🔥 Trending: *%s*\n\n", "Yes! 🚀")
-			} else {
-				msg += fmt.Sprintf("🔥 Trending: *%s*\n\n", "No ❄️")
-			}
-			if errCommunity == nil {
-				msg += fmt.Sprintf("👥 *Followers on CMC:* `%s`\n", stringNumberToHumanize(community.Followers))
-				msg += fmt.Sprintf("⭐ *Watchlist Count:* `%s`\n", stringNumberToHumanize(community.WatchCount))
-			}
-
-			//degeu
-			if histo.Symbol == "RLC" {
-				tweets, errTweets := service.twitterService.GetYesterdayTweets()
-				if errTweets == nil && len(tweets) > 0 {
-					msg += "🔥 *Twitter Highlights from Yesterday*\n\n"
-					for _, tweet := range tweets {
-						msg += "🔗 [Tweet Link](" + tweet.PermanentURL + ")\n"
-					}
-
-				} else {
-					msg += "No Twitter activity yesterday.\n"
-				}
-			}
-
-			msg += "\n"
-		}
-
-		msg += "\n"
-		msg += "📆 Data from *yesterday*. Stay tuned for more updates! 📈\n\n"
-		msg += "⚠️ The report is based on yesterday's data, so 7-day data actually means today minus 8 days.\n"
-
-		if ok {
-			service.cache.Set("daily_report", msg, cache.NoExpiration)
-		}
+// sendMessage sends a Markdown message and counts it, successful or not, for the given command.
+func (service *Impl) sendMessage(command string, chatID int64, text string) {
+	_, err := service.bot.SendMessage(chatID, text, &gotgbot.SendMessageOpts{ParseMode: "Markdown"})
+	metrics.TelegramMessage(command, err)
+	if err != nil {
+		log.Error().Err(err).Str("cmd", command).Int64("chatID", chatID).Msg("Cannot send message")
 	}
+}
 
+// sendPhoto sends a PNG image with a Markdown caption and counts it for the given command.
+func (service *Impl) sendPhoto(command string, chatID int64, name string, image io.Reader, caption string) {
+	_, err := service.bot.SendPhoto(chatID, gotgbot.InputFileByReader(name, image), &gotgbot.SendPhotoOpts{Caption: caption, ParseMode: "Markdown"})
+	metrics.TelegramMessage(command, err)
+	if err != nil {
+		log.Error().Err(err).Str("cmd", command).Int64("chatID", chatID).Msg("Cannot send photo")
+	}
 }
 
 func (service *Impl) isASubscriber(chatID int64) bool {
@@ -479,9 +451,17 @@ func (service *Impl) OnNotify(e observer.Event) {
 	if e.E == observer.TrendingEvent {
 		service.tendringNotify()
 	} else if e.E == observer.RSSEvent {
-		service.bot.SendMessage(constants.TelegramAdmin, e.Feed.Title, &gotgbot.SendMessageOpts{ParseMode: "Markdown"})
+		service.sendNews(e.Source, e.Feed, e.Symbols)
+	} else if e.E == observer.PriceEvent {
+		// The live quotes of the report are read on render, its cached sections stay valid.
+		service.sendAlerts()
+	} else if e.E == observer.RankingEvent {
+		service.reportService.Generate()
+		service.sendAlerts()
+		service.sendRankAlerts()
 	} else {
-		service.generateReport()
+		service.reportService.Generate()
+		service.sendAlerts()
 	}
 
 }
@@ -506,19 +486,46 @@ func (service *Impl) sendDailyIndicator(chatID int64) {
 				"📊 *Market Sentiment Update* ( _ exprimental feature _ )\n\n"+
 					"💰 *Market Cap:* %s\n"+
 					"🏛 *BTC Dominance:* `%.2f%%`\n"+
-					"🧭 *Fear & Greed Index:* %s `%d/100` (%s)\n\n"+
-					"👉 *Market Insight:* %s",
-				humanize.Comma(indicator.TotalMarketCap), indicator.BtcDominance, emoji, indicator.FearGreedIndex, sentiment, getInsight(indicator.FearGreedIndex),
+					"🔷 *ETH Dominance:* `%.2f%%`\n"+
+					"🧭 *Fear & Greed Index:* %s `%d/100` (%s)\n",
+				humanize.Comma(indicator.TotalMarketCap), indicator.BtcDominance, indicator.EthDominance, emoji, indicator.FearGreedIndex, sentiment,
 			)
+			message += fearGreedComparison("yesterday", indicator.FearGreedIndex, indicator.FearGreedYesterdayIndex)
+			message += fearGreedComparison("last week", indicator.FearGreedIndex, indicator.FearGreedLastWeekIndex)
+			message += fearGreedComparison("last month", indicator.FearGreedIndex, indicator.FearGreedLastMonthIndex)
+			message += "\n"
+			message += service.marketTrendMessage()
+			message += fmt.Sprintf("👉 *Market Insight:* %s", getInsight(indicator.FearGreedIndex))
 			for _, user := range users {
 				log.Info().Str("cmd", "report").Int64("chatID", user.ChatID).Msg("send indicator")
-				service.bot.SendMessage(user.ChatID, message, &gotgbot.SendMessageOpts{ParseMode: "Markdown"})
+				service.sendMessage("daily_indicator", user.ChatID, message)
 			}
 
 		}
 	}
 }
 
+// marketTrendMessage compares dominance and market cap with stored indicators
+// of last week and last month; empty while the history is too short.
+func (service *Impl) marketTrendMessage() string {
+	msg := ""
+	for _, window := range []struct {
+		label  string
+		period time.Duration
+	}{{"7 days", 7 * 24 * time.Hour}, {"30 days", 30 * 24 * time.Hour}} {
+		trend, err := service.cryptorankService.GetMarketTrend(window.period)
+		if err != nil {
+			continue
+		}
+		msg += fmt.Sprintf("📅 *%s:* Market Cap `%+.2f%%`, BTC Dom. `%+.2fpt`, ETH Dom. `%+.2fpt`\n",
+			window.label, trend.TotalMarketCapChangePercent(), trend.BtcDominanceDelta(), trend.EthDominanceDelta())
+	}
+	if msg != "" {
+		msg += "\n"
+	}
+	return msg
+}
+
 func (service *Impl) sendDailyReport(chatID int64) {
 	log.Info().Msg("Send daily report")
 	var users []entities.TelegramUser
@@ -529,53 +536,33 @@ func (service *Impl) sendDailyReport(chatID int64) {
 		users, err = service.telegramRepo.FetchAll()
 	}
 
-	var message string
-	if x, found := service.cache.Get("daily_report"); found {
-		message = x.(string)
-		if len(message) > 0 && err == nil {
-			for _, user := range users {
-				log.Info().Str("cmd", "report").Int64("chatID", user.ChatID).Msg("send report")
-				service.bot.SendMessage(user.ChatID, message, &gotgbot.SendMessageOpts{ParseMode: "Markdown"})
-			}
-		}
-	} else {
-		log.Warn().Str("cmd", "report").Msg("No report")
+	if err != nil {
+		log.Error().Err(err).Str("cmd", "report").Msg("Cannot fetch users")
+		return
 	}
 
-	/**
-	cryptocurrencies := constants.GetCrytoWatch()
-	if err == nil && len(users) > 0 && len(cryptocurrencies) > 0 {
-		msg := "📢 *Daily Crypto Report* 🚀\n\n"
-		for _, crycryptocurrency := range cryptocurrencies {
-			msg += "🔹 *" + crycryptocurrency.Desc + "*\n"
-			histo, errPrice := service.cmcService.FetchForSymbolYesterday(crycryptocurrency.Symbol)
-			trendy := service.cmcService.IsCryptoTrendyYersterday(crycryptocurrency.Symbol)
-
-			if errPrice == nil {
-				msg += fmt.Sprintf("💰 Price: `$%.2f`\n", histo.Price)
-				msg += fmt.Sprintf("📊 Rank: `#%d`\n", histo.Rank)
-				msg += fmt.Sprintf("🏛 Market Cap: `$%.2f`\n", histo.Marketcap)
-			}
-			if trendy {
-				msg += fmt.Sprintf("🔥 Trending: *%s*\n\n", "Yes! 🚀")
-			} else {
-				msg += fmt.Sprintf("🔥 Trending: *%s*\n\n", "No ❄️")
-			}
-			msg += "\n"
+	for _, user := range users {
+		message, ok := service.reportService.Render(service.getUserTokens(user.ChatID))
+		if !ok {
+			log.Warn().Str("cmd", "report").Int64("chatID", user.ChatID).Msg("No report")
+			continue
 		}
+		log.Info().Str("cmd", "report").Int64("chatID", user.ChatID).Msg("send report")
+		service.sendMessage("daily_report", user.ChatID, message)
 
-		msg += "📆 Data from *yesterday*. Stay tuned for more updates! 📈\n"
-
-		for _, user := range users {
-			log.Info().Str("cmd", "report").Int64("chatID", user.ChatID).Msg("send report")
-			service.bot.SendMessage(user.ChatID, msg, &gotgbot.SendMessageOpts{ParseMode: "Markdown"})
+		if viper.GetBool(constants.ReportSparklines) {
+			service.sendSparklines(user.ChatID)
 		}
-
-	} else {
-		log.Warn().Str("cmd", "report").Msg("No User")
 	}
-		**/
+}
 
+func (service *Impl) sendSparklines(chatID int64) {
+	image, err := service.reportService.RenderSparklines(service.getUserTokens(chatID))
+	if err != nil {
+		log.Warn().Err(err).Str("cmd", "report").Int64("chatID", chatID).Msg("No sparklines")
+		return
+	}
+	service.sendPhoto("daily_report", chatID, "sparklines.png", bytes.NewReader(image), "")
 }
 
 func getGenericErrorMEssage() string {
@@ -608,11 +595,26 @@ func getMessageFromMessageType(messageType MessageType) string {
 		msg += "- `/subscribe` – Start receiving daily reports. 🤝\n"
 		msg += "- `/unsubscribe` – Stop receiving daily reports. 👋\n"
 		msg += "- `/report` – Get the latest RLC report instantly. 📊\n"
+		msg += "- `/watchlist` – List the tokens followed in the report. 👀\n"
 		msg += "- `/help` – Show this help message. 💡\n\n"
 
 		msg += "\n"
 		msg += "🚀 *Subscribers Features:* \n"
 		msg += "- `/tokens <symbol1> [symbol2] .. [symbol5]` - Get report for this token (only TOP 1000). 🔍\n"
+		msg += "- `/watch add|remove <symbol>` - Build your own daily report. 👀\n"
+		msg += "- `/watch list` - Show the tokens of your daily report. 📋\n"
+		msg += "- `/alert add <symbol> above|below <price>` - Get notified when a price is crossed. 🔔\n"
+		msg += "- `/alert add <symbol> move <percent>` - Get notified on a 24h move. 🎢\n"
+		msg += "- `/alert list` | `/alert delete <id>` - Manage your alerts. 🗂\n"
+		msg += "- `/sentiment [7d|30d|90d|1y]` - Chart of the Fear & Greed index. 🧭\n"
+		msg += "- `/movers [1d|7d|30d] [n]` - Top gainers and losers among liquid tokens. 🎢\n"
+		msg += "- `/compare <symbol>` - Performance against its competitors. 🥊\n"
+		msg += "- `/community <symbol>` - Growth of the CMC followers and watchlist count. 👥\n"
+		msg += "- `/news <symbol>` - Latest articles mentioning a token. 📰\n"
+		msg += "- `/search <query>` - Most relevant articles of the last 30 days. 🔎\n"
+		msg += "- `/sectors [1d|7d|30d]` - Market cap and median return of each sector. 🧩\n"
+		msg += "- `/ranks [1d|7d|30d]` - Biggest rank climbers and fallers of the top 1000. 🏆\n"
+		msg += "- `/chart <symbol> [7d|30d|90d|since-halving] [price|candles|rank|mcap]` - Chart of a token history. 📈\n"
 		msg += "\n"
 		msg += "🔗 Stay ahead with the latest RLC data!\n"
 		return msg
@@ -640,35 +642,6 @@ func getMessageFromMessageType(messageType MessageType) string {
 	}
 }
 
-// GenerateTokenSentence generates a sentence describing the token's performance
-func GenerateTokenSentence(symbol string, yesterdayPrice, twoDaysAgoPrice float64) string {
-	// Compute the percentage change over 2 days
-	percentChange := ((yesterdayPrice - twoDaysAgoPrice) / twoDaysAgoPrice) * 100
-
-	// Get the proper name ($BTC or $ETH)
-	tokenName := fmt.Sprintf("$%s", symbol)
-
-	// Generate sentence based on percentage change
-	if math.Abs(percentChange) <= 2 {
-		return fmt.Sprintf("%s remains stable at $%.0f, with a slight %.2f%% move over the past two days.", tokenName, yesterdayPrice, percentChange)
-	} else if percentChange > 2 {
-		return fmt.Sprintf("%s continues its bullish momentum, rising to $%.0f (+%.2f%%) in the last two days.", tokenName, yesterdayPrice, percentChange)
-	} else {
-		return fmt.Sprintf("%s is facing some pressure, dropping to $%.0f (-%.2f%%) over the last two days.", tokenName, yesterdayPrice, math.Abs(percentChange))
-	}
-}
-
-func stringNumberToHumanize(value string) string {
-	if len(value) == 0 {
-		return value
-	}
-	s, err := strconv.ParseFloat(value, 64)
-	if err != nil {
-		return value
-	}
-	return humanize.CommafWithDigits(s, 0)
-}
-
 func getSentiment(index int) (emoji string, sentiment string) {
 	switch {
 	case index <= 20:
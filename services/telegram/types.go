@@ -2,10 +2,18 @@ package telegram
 
 import (
 	telegramRepo "crypto-analytics/repositories/telegram"
+	userTokensRepo "crypto-analytics/repositories/usertokens"
+	"crypto-analytics/services/alerts"
+	"crypto-analytics/services/analytics"
 	cmcService "crypto-analytics/services/coinmarketcap"
 	"crypto-analytics/services/cryptorank"
+	"crypto-analytics/services/feeds"
+	"crypto-analytics/services/report"
 	twitterService "crypto-analytics/services/twitter"
+	"crypto-analytics/services/watchlist"
+	"crypto-analytics/utils/insights"
 	"errors"
+	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/PaulSonOfLars/gotgbot/v2/ext"
@@ -24,6 +32,48 @@ const (
 	MessageTypeUnsubscribe MessageType = 5
 )
 
+const (
+	maxUserTokens = 10
+	// Updates are long polled every few seconds.
+	pollerMaxAge = 1 * time.Minute
+	// Window of /sentiment without argument.
+	defaultSentimentWindow = "30d"
+	// Window and kind of /chart without argument.
+	defaultChartWindow = "30d"
+	defaultChartKind   = "price"
+	sinceHalvingWindow = "since-halving"
+	// Window of /ranks without argument and climbers or fallers listed.
+	defaultRanksWindow = "7d"
+	rankMoversCount    = 10
+	// Window and count of /movers without argument.
+	defaultMoversWindow = "1d"
+	defaultMoversCount  = 5
+	maxMoversCount      = 20
+	// Window of /sectors without argument and tokens named per sector.
+	defaultSectorsWindow = "7d"
+	sectorLeadersCount   = 3
+	// Characters of the summary of a news before being cut.
+	newsSummaryLength = 300
+	// Articles listed by /news.
+	newsListCount = 10
+)
+
+var (
+	// Days covered by each /sentiment window.
+	sentimentWindows = map[string]int{"7d": 7, "30d": 30, "90d": 90, "1y": 365}
+	// Days covered by each /chart window, the halving one being computed on the fly.
+	chartWindows = map[string]int{"7d": 7, "30d": 30, "90d": 90, sinceHalvingWindow: 0}
+	chartKinds   = map[string]bool{"price": true, "candles": true, "rank": true, "mcap": true}
+	// Days covered by each /ranks window.
+	ranksWindows = map[string]int{"1d": 1, "7d": 7, "30d": 30}
+	// Days covered by each /movers window.
+	moversWindows = map[string]int{"1d": 1, "7d": 7, "30d": 30}
+	// Days covered by each /sectors window.
+	sectorsWindows = map[string]int{"1d": 1, "7d": 7, "30d": 30}
+	// Days compared by /compare.
+	compareWindows = []int{7, 30}
+)
+
 var (
 	ErrTokenIsMissing         = errors.New("telegram token is missing")
 	ErrBotNotInitialized      = errors.New("telegram bot  is not ready yet")
@@ -32,14 +82,22 @@ var (
 
 type Service interface {
 	ListenAndDispatch() error
+	RegisterHealthChecks(probes insights.Probes)
 }
 
 type Impl struct {
 	bot               *gotgbot.Bot
 	updater           *ext.Updater
 	telegramRepo      telegramRepo.Repository
+	userTokensRepo    userTokensRepo.Repository
 	cmcService        cmcService.Service
 	twitterService    twitterService.Service
 	cryptorankService cryptorank.Service
+	watchlistService  watchlist.Service
+	alertsService     alerts.Service
+	reportService     report.Service
+	analyticsService  analytics.Service
+	feedsService      feeds.Service
 	cache             *cache.Cache
+	pollerBeat        *insights.Heartbeat
 }
@@ -0,0 +1,108 @@
+package telegram
+
+import (
+	"crypto-analytics/models/entities"
+	"fmt"
+	"strings"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/rs/zerolog/log"
+)
+
+// watchCmd manages the personal token list of a subscriber:
+// /watch add <SYMBOL>, /watch remove <SYMBOL>, /watch list.
+func (service *Impl) watchCmd(b *gotgbot.Bot, ctx *ext.Context) error {
+	log.Info().Str("cmd", "watch").Str("username", ctx.EffectiveChat.Username).Int64("chatID", ctx.EffectiveChat.Id).Msg("command received")
+
+	if !service.isASubscriber(ctx.EffectiveChat.Id) {
+		msg := "⚠️ This feature is only available for subscribers !\n"
+		service.sendMessage("watch", ctx.EffectiveChat.Id, msg)
+		return nil
+	}
+
+	args := strings.Fields(ctx.Message.GetText())[1:]
+	var msg string
+	switch {
+	case len(args) == 0 || strings.EqualFold(args[0], "list"):
+		msg = service.userTokensMessage(ctx.EffectiveChat.Id)
+	case strings.EqualFold(args[0], "add") && len(args) == 2:
+		msg = service.addUserToken(ctx.EffectiveChat.Id, strings.ToUpper(args[1]))
+	case strings.EqualFold(args[0], "remove") && len(args) == 2:
+		msg = service.removeUserToken(ctx.EffectiveChat.Id, strings.ToUpper(args[1]))
+	default:
+		msg = "Usage: `/watch add <SYMBOL>`, `/watch remove <SYMBOL>` or `/watch list`"
+	}
+
+	service.sendMessage("watch", ctx.EffectiveChat.Id, msg)
+	return nil
+}
+
+func (service *Impl) addUserToken(chatID int64, symbol string) string {
+	if service.userTokensRepo.CountForUser(chatID) >= maxUserTokens {
+		return fmt.Sprintf("⚠️ You can follow at most %d tokens.", maxUserTokens)
+	}
+
+	if _, err := service.cmcService.FetchForSymbolYesterday(symbol); err != nil {
+		return fmt.Sprintf("⚠️ *%s* is unknown (only TOP 1000 tokens are available).", symbol)
+	}
+
+	if err := service.userTokensRepo.Save(entities.UserToken{ChatID: chatID, Symbol: symbol}); err != nil {
+		log.Error().Err(err).Int64("chatID", chatID).Str("symbol", symbol).Msg("cannot save user token")
+		return getGenericErrorMEssage()
+	}
+
+	return fmt.Sprintf("✅ *%s* added to your watchlist.", symbol)
+}
+
+func (service *Impl) removeUserToken(chatID int64, symbol string) string {
+	if err := service.userTokensRepo.Delete(entities.UserToken{ChatID: chatID, Symbol: symbol}); err != nil {
+		log.Error().Err(err).Int64("chatID", chatID).Str("symbol", symbol).Msg("cannot delete user token")
+		return getGenericErrorMEssage()
+	}
+
+	return fmt.Sprintf("🗑 *%s* removed from your watchlist.", symbol)
+}
+
+func (service *Impl) userTokensMessage(chatID int64) string {
+	tokens, err := service.userTokensRepo.FetchForUser(chatID)
+	if err != nil || len(tokens) == 0 {
+		return "👀 Your watchlist is empty, the report follows the default tokens.\n\nUse `/watch add <SYMBOL>` to build your own."
+	}
+
+	msg := "👀 *Your watchlist*\n\n"
+	for _, token := range tokens {
+		msg += fmt.Sprintf("🔹 *%s*\n", token.Symbol)
+	}
+	return msg
+}
+
+// getUserTokens returns the personal watchlist of a chat, or the global
+// watchlist when the user did not pick any token.
+func (service *Impl) getUserTokens(chatID int64) []entities.WatchedToken {
+	userTokens, err := service.userTokensRepo.FetchForUser(chatID)
+	if err != nil || len(userTokens) == 0 {
+		return service.watchlistService.GetWatchlist()
+	}
+
+	tokens := make([]entities.WatchedToken, 0, len(userTokens))
+	for _, userToken := range userTokens {
+		tokens = append(tokens, service.resolveToken(userToken.Symbol))
+	}
+	return tokens
+}
+
+// resolveToken returns the watched token for this symbol, or builds one from
+// the latest historical data when it is not part of the global watchlist.
+func (service *Impl) resolveToken(symbol string) entities.WatchedToken {
+	if token, found := service.watchlistService.FindBySymbol(symbol); found {
+		return token
+	}
+
+	token := entities.WatchedToken{Symbol: symbol, Desc: symbol}
+	if histo, err := service.cmcService.FetchForSymbolYesterday(symbol); err == nil {
+		token.CryptoID = histo.ID
+		token.Desc = fmt.Sprintf("%s (%s)", histo.Name, histo.Symbol)
+	}
+	return token
+}
@@ -0,0 +1,98 @@
+package telegram
+
+import (
+	"crypto-analytics/models/constants"
+	"crypto-analytics/models/entities"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/rs/zerolog/log"
+)
+
+// watchlistCmd lists the watched tokens; admin can also add or remove entries:
+// /watchlist add <cmcID> <SYMBOL> [handle] [description...]
+// /watchlist remove <SYMBOL>.
+func (service *Impl) watchlistCmd(b *gotgbot.Bot, ctx *ext.Context) error {
+	log.Info().Str("cmd", "watchlist").Str("username", ctx.EffectiveChat.Username).Int64("chatID", ctx.EffectiveChat.Id).Msg("command received")
+	args := strings.Fields(ctx.Message.GetText())[1:]
+
+	if len(args) == 0 {
+		service.sendMessage("watchlist", ctx.EffectiveChat.Id, service.watchlistMessage())
+		return nil
+	}
+
+	if ctx.EffectiveChat.Id != constants.TelegramAdmin {
+		log.Warn().Str("cmd", "watchlist").Int64("chatID", ctx.EffectiveChat.Id).Msg("forbidden usage")
+		return nil
+	}
+
+	var msg string
+	switch strings.ToLower(args[0]) {
+	case "add":
+		msg = service.addToWatchlist(args[1:])
+	case "remove":
+		msg = service.removeFromWatchlist(args[1:])
+	default:
+		msg = "Usage: `/watchlist [add <cmcID> <SYMBOL> [handle] [description]|remove <SYMBOL>]`"
+	}
+
+	service.sendMessage("watchlist", ctx.EffectiveChat.Id, msg)
+	return nil
+}
+
+func (service *Impl) addToWatchlist(args []string) string {
+	if len(args) < 2 {
+		return "Usage: `/watchlist add <cmcID> <SYMBOL> [handle] [description]`"
+	}
+
+	cryptoID, err := strconv.Atoi(args[0])
+	if err != nil {
+		return "⚠️ The CMC id must be a number."
+	}
+
+	token := entities.WatchedToken{CryptoID: cryptoID, Symbol: args[1]}
+	if len(args) > 2 {
+		token.Handle = args[2]
+	}
+	if len(args) > 3 {
+		token.Desc = strings.Join(args[3:], " ")
+	}
+
+	if errAdd := service.watchlistService.Add(token); errAdd != nil {
+		log.Error().Err(errAdd).Str("symbol", token.Symbol).Msg("cannot add token to watchlist")
+		return "⚠️ Cannot add this token: " + errAdd.Error()
+	}
+
+	service.reportService.Generate()
+	return fmt.Sprintf("✅ *%s* added to the watchlist.", strings.ToUpper(token.Symbol))
+}
+
+func (service *Impl) removeFromWatchlist(args []string) string {
+	if len(args) != 1 {
+		return "Usage: `/watchlist remove <SYMBOL>`"
+	}
+
+	if err := service.watchlistService.Remove(args[0]); err != nil {
+		log.Error().Err(err).Str("symbol", args[0]).Msg("cannot remove token from watchlist")
+		return "⚠️ Cannot remove this token: " + err.Error()
+	}
+
+	service.reportService.Generate()
+	return fmt.Sprintf("🗑 *%s* removed from the watchlist.", strings.ToUpper(args[0]))
+}
+
+func (service *Impl) watchlistMessage() string {
+	tokens := service.watchlistService.GetWatchlist()
+	if len(tokens) == 0 {
+		return "👀 The watchlist is empty."
+	}
+
+	msg := "👀 *Watched tokens*\n\n"
+	for _, token := range tokens {
+		msg += fmt.Sprintf("🔹 *%s* – %s (CMC `#%d`)\n", token.Symbol, token.Desc, token.CryptoID)
+	}
+	return msg
+}
@@ -3,8 +3,11 @@ package twitter
 import (
 	repo "crypto-analytics/repositories/twitter"
 	"crypto-analytics/utils/dates"
+	"crypto-analytics/utils/insights"
+	"errors"
 	"sort"
 	"sync"
+	"time"
 
 	"crypto-analytics/models/constants"
 	"crypto-analytics/models/entities"
@@ -18,14 +21,7 @@ import (
 func New(scheduler gocron.Scheduler,
 	repository repo.Repository,
 	accounts []constants.TwitterAccount) (*Impl, error) {
-	service := &Impl{
-		accounts:   accounts,
-		repository: repository,
-		authToken:  viper.GetString(constants.TwitterAuthToken),
-		csrfToken:  viper.GetString(constants.TwitterCSRFToken),
-		tweetCount: viper.GetInt(constants.TwitterTweetCount),
-		scraper:    twitterscraper.New(),
-	}
+	service := NewService(repository, accounts)
 
 	if viper.GetBool(constants.Production) {
 		service.fetchAndSaveTweets()
@@ -33,7 +29,7 @@ func New(scheduler gocron.Scheduler,
 
 	_, errJob := scheduler.NewJob(
 		gocron.CronJob("*/15 * * * *", true),
-		gocron.NewTask(func() { service.fetchAndSaveTweets() }),
+		gocron.NewTask(func() error { return service.fetchAndSaveTweets() }),
 		gocron.WithName("Fetch twitter accounts"),
 	)
 	if errJob != nil {
@@ -43,8 +39,28 @@ func New(scheduler gocron.Scheduler,
 	return service, nil
 }
 
+// NewService builds the service without scraping nor scheduling anything.
+func NewService(repository repo.Repository, accounts []constants.TwitterAccount) *Impl {
+	return &Impl{
+		accounts:   accounts,
+		repository: repository,
+		authToken:  viper.GetString(constants.TwitterAuthToken),
+		csrfToken:  viper.GetString(constants.TwitterCSRFToken),
+		tweetCount: viper.GetInt(constants.TwitterTweetCount),
+		scraper:    twitterscraper.New(),
+	}
+}
+
+func (service *Impl) RegisterHealthChecks(probes insights.Probes) {
+	probes.AddCheck("twitter-scrape", false, service.scrapeBeat.Check(viper.GetDuration(constants.HealthTwitterMaxAge)))
+}
+
 func (service *Impl) GetYesterdayTweets() ([]entities.Tweet, error) {
-	start, end := dates.GetYesterdayTimestamps()
+	return service.GetTweetsForDay(time.Now().AddDate(0, 0, -1))
+}
+
+func (service *Impl) GetTweetsForDay(day time.Time) ([]entities.Tweet, error) {
+	start, end := dates.GetDayTimestamps(day)
 	log.Info().Int64("start", start).Int64("end", end).Msg("Start fetching tweets")
 	tweets, err := service.repository.GetTweetBetweenTimestamps(start, end)
 	if err != nil {
@@ -54,22 +70,24 @@ func (service *Impl) GetYesterdayTweets() ([]entities.Tweet, error) {
 	return filterRootTweets(tweets), nil
 }
 
-func (service *Impl) fetchAndSaveTweets() {
+func (service *Impl) fetchAndSaveTweets() error {
 	log.Info().Msg("Start fetching twitter accounts")
 	var wg sync.WaitGroup
-	for _, account := range service.accounts {
+	errs := make([]error, len(service.accounts))
+	for i, account := range service.accounts {
 		wg.Add(1)
-		go func(twitterAccount constants.TwitterAccount) {
+		go func(i int, twitterAccount constants.TwitterAccount) {
 			defer wg.Done()
-			service.checkTwitterAccount(twitterAccount)
-		}(account)
+			errs[i] = service.checkTwitterAccount(twitterAccount)
+		}(i, account)
 	}
 
 	wg.Wait()
 	log.Info().Msg("End fetching twitter accounts")
+	return errors.Join(errs...)
 }
 
-func (service *Impl) checkTwitterAccount(account constants.TwitterAccount) {
+func (service *Impl) checkTwitterAccount(account constants.TwitterAccount) error {
 	log.Info().
 		Str(constants.LogTwitterName, account.Name).
 		Str(constants.LogTwitterID, account.ID).
@@ -81,9 +99,10 @@ func (service *Impl) checkTwitterAccount(account constants.TwitterAccount) {
 		log.Error().Err(err).
 			Str(constants.LogTwitterID, account.ID).
 			Msgf("Cannot retrieve tweets from account, ignored")
-		return
+		return err
 	}
 
+	service.scrapeBeat.Beat()
 	tweets = service.keepInterestingTweets(tweets)
 
 	for _, tweet := range tweets {
@@ -91,6 +110,7 @@ func (service *Impl) checkTwitterAccount(account constants.TwitterAccount) {
 		service.repository.SaveOrUpdate(tweetToSave)
 
 	}
+	return nil
 }
 
 func (service *Impl) keepInterestingTweets(tweets []*twitterscraper.Tweet) []*twitterscraper.Tweet {
@@ -4,12 +4,16 @@ import (
 	"crypto-analytics/models/constants"
 	"crypto-analytics/models/entities"
 	repo "crypto-analytics/repositories/twitter"
+	"crypto-analytics/utils/insights"
+	"time"
 
 	twitterscraper "github.com/n0madic/twitter-scraper"
 )
 
 type Service interface {
 	GetYesterdayTweets() ([]entities.Tweet, error)
+	GetTweetsForDay(day time.Time) ([]entities.Tweet, error)
+	RegisterHealthChecks(probes insights.Probes)
 }
 
 type Impl struct {
@@ -19,4 +23,5 @@ type Impl struct {
 	scraper    *twitterscraper.Scraper
 	repository repo.Repository
 	accounts   []constants.TwitterAccount
+	scrapeBeat insights.Heartbeat
 }
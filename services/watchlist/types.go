@@ -0,0 +1,29 @@
+package watchlist
+
+import (
+	"crypto-analytics/models/entities"
+	"crypto-analytics/repositories/watchlist"
+	"errors"
+	"sync"
+)
+
+var (
+	ErrInvalidToken  = errors.New("watched token must have a CMC id and a symbol")
+	ErrTokenNotFound = errors.New("token is not in the watchlist")
+)
+
+type Service interface {
+	GetWatchlist() []entities.WatchedToken
+	FindBySymbol(symbol string) (entities.WatchedToken, bool)
+	Add(token entities.WatchedToken) error
+	Remove(symbol string) error
+	GetPeers(symbol string) []string
+	GetAllPeers() []string
+}
+
+type Impl struct {
+	repository watchlist.Repository
+	tokens     []entities.WatchedToken
+	peers      map[string][]string
+	mutex      sync.RWMutex
+}
@@ -0,0 +1,179 @@
+package watchlist
+
+import (
+	"crypto-analytics/models/constants"
+	"crypto-analytics/models/entities"
+	"crypto-analytics/repositories/watchlist"
+	"crypto-analytics/utils/configs"
+	"sort"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// New keeps the persisted watchlist in memory, seeding it from the configuration
+// only when empty. Tokens added or removed at runtime are kept across restarts,
+// a token removed by the admin does not come back from the configuration.
+func New(repository watchlist.Repository) (*Impl, error) {
+	configured, err := configs.Load[[]entities.WatchedToken](constants.CryptoWatchlist)
+	if err != nil {
+		return nil, err
+	}
+
+	if repository.Count() == 0 {
+		for _, token := range configured {
+			if errSave := repository.Save(normalize(token)); errSave != nil {
+				return nil, errSave
+			}
+		}
+	}
+
+	return load(repository)
+}
+
+// NewReadOnly loads the watchlist without writing it, e.g. for the one-off commands of the CLI.
+// Until the daemon seeded the database, the configured tokens are kept in memory only.
+func NewReadOnly(repository watchlist.Repository) (*Impl, error) {
+	service, err := load(repository)
+	if err != nil || len(service.tokens) > 0 {
+		return service, err
+	}
+
+	configured, err := configs.Load[[]entities.WatchedToken](constants.CryptoWatchlist)
+	if err != nil {
+		return nil, err
+	}
+	for _, token := range configured {
+		service.tokens = append(service.tokens, normalize(token))
+	}
+	return service, nil
+}
+
+func load(repository watchlist.Repository) (*Impl, error) {
+	peers, err := loadPeersFromConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	service := &Impl{
+		repository: repository,
+		peers:      peers,
+	}
+	if errRefresh := service.refresh(); errRefresh != nil {
+		return nil, errRefresh
+	}
+
+	log.Info().Int("tokens", len(service.tokens)).Msg("Watchlist loaded")
+	return service, nil
+}
+
+func (service *Impl) GetWatchlist() []entities.WatchedToken {
+	service.mutex.RLock()
+	defer service.mutex.RUnlock()
+
+	tokens := make([]entities.WatchedToken, len(service.tokens))
+	copy(tokens, service.tokens)
+	return tokens
+}
+
+func (service *Impl) FindBySymbol(symbol string) (entities.WatchedToken, bool) {
+	service.mutex.RLock()
+	defer service.mutex.RUnlock()
+
+	for _, token := range service.tokens {
+		if strings.EqualFold(token.Symbol, symbol) {
+			return token, true
+		}
+	}
+	return entities.WatchedToken{}, false
+}
+
+// GetPeers returns the symbols of the configured competitors of a token.
+func (service *Impl) GetPeers(symbol string) []string {
+	peers := service.peers[strings.ToUpper(symbol)]
+	result := make([]string, len(peers))
+	copy(result, peers)
+	return result
+}
+
+// GetAllPeers returns the symbols of every configured competitor, without duplicates.
+func (service *Impl) GetAllPeers() []string {
+	seen := make(map[string]bool)
+	var result []string
+	for _, peers := range service.peers {
+		for _, peer := range peers {
+			if !seen[peer] {
+				seen[peer] = true
+				result = append(result, peer)
+			}
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+func (service *Impl) Add(token entities.WatchedToken) error {
+	token = normalize(token)
+	if token.CryptoID <= 0 || token.Symbol == "" {
+		return ErrInvalidToken
+	}
+
+	if err := service.repository.Save(token); err != nil {
+		return err
+	}
+
+	return service.refresh()
+}
+
+func (service *Impl) Remove(symbol string) error {
+	token, found := service.FindBySymbol(symbol)
+	if !found {
+		return ErrTokenNotFound
+	}
+
+	if err := service.repository.Delete(token); err != nil {
+		return err
+	}
+
+	return service.refresh()
+}
+
+func (service *Impl) refresh() error {
+	tokens, err := service.repository.FetchAll()
+	if err != nil {
+		return err
+	}
+
+	service.mutex.Lock()
+	service.tokens = tokens
+	service.mutex.Unlock()
+	return nil
+}
+
+// loadPeersFromConfig reads the competitors of the tokens, keyed and valued by symbols.
+func loadPeersFromConfig() (map[string][]string, error) {
+	raw, err := configs.Load[map[string][]string](constants.PeerGroups)
+	if err != nil {
+		return nil, err
+	}
+
+	peers := make(map[string][]string, len(raw))
+	for symbol, group := range raw {
+		symbol = strings.ToUpper(strings.TrimSpace(symbol))
+		for _, peer := range group {
+			if peer = strings.ToUpper(strings.TrimSpace(peer)); peer != "" && peer != symbol {
+				peers[symbol] = append(peers[symbol], peer)
+			}
+		}
+	}
+	return peers, nil
+}
+
+func normalize(token entities.WatchedToken) entities.WatchedToken {
+	token.Symbol = strings.ToUpper(strings.TrimSpace(token.Symbol))
+	token.Handle = strings.TrimSpace(token.Handle)
+	if token.Desc == "" {
+		token.Desc = token.Symbol
+	}
+	return token
+}
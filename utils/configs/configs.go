@@ -0,0 +1,30 @@
+package configs
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// Load decodes a structured setting, given either as a JSON string (env or .env file)
+// or as a list or map (YAML/JSON config file); an empty setting gives the zero value.
+func Load[T any](key string) (T, error) {
+	var value T
+
+	if raw, ok := viper.Get(key).(string); ok {
+		if strings.TrimSpace(raw) == "" {
+			return value, nil
+		}
+		if err := json.Unmarshal([]byte(raw), &value); err != nil {
+			return value, fmt.Errorf("failed to parse %s: %w", key, err)
+		}
+		return value, nil
+	}
+
+	if err := viper.UnmarshalKey(key, &value); err != nil {
+		return value, fmt.Errorf("failed to parse %s: %w", key, err)
+	}
+	return value, nil
+}
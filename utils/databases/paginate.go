@@ -0,0 +1,13 @@
+package databases
+
+import "gorm.io/gorm"
+
+// Paginate counts the rows matched by query then loads the requested window into dest.
+func Paginate(query *gorm.DB, offset int, limit int, dest any) (int64, error) {
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return 0, err
+	}
+
+	return total, query.Offset(offset).Limit(limit).Find(dest).Error
+}
@@ -21,6 +21,14 @@ func New() SqlConnection {
 	}
 }
 
+// NewInMemory returns a connection to a private in-memory database, e.g. for tests.
+// Connections opened with the same name share it until the last one is closed.
+func NewInMemory(name string) SqlConnection {
+	return &sqliteConnection{
+		dsn: "file:" + name + "?mode=memory&cache=shared",
+	}
+}
+
 func (c *sqliteConnection) GetDB() *gorm.DB {
 	return c.db
 }
@@ -1,6 +1,9 @@
 package dates
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 const (
 	DateFormat = "2006-01-02"
@@ -41,19 +44,25 @@ func DateToString(from time.Time, dateFormat string) string {
 
 // GetYesterdayTimestamps returns the start and end timestamps (Unix time in seconds) for yesterday's date
 func GetYesterdayTimestamps() (int64, int64) {
-	now := time.Now()
-
-	// Get start of today (midnight)
-	startOfToday := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	return GetDayTimestamps(time.Now().AddDate(0, 0, -1))
+}
 
-	// Start of yesterday (midnight)
-	startOfYesterday := startOfToday.AddDate(0, 0, -1)
+// GetDayTimestamps returns the start and end timestamps (Unix time in seconds) of the given day
+func GetDayTimestamps(day time.Time) (int64, int64) {
+	// Start of the day (midnight)
+	startOfDay := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
 
-	// End of yesterday (23:59:59)
-	endOfYesterday := startOfToday.Add(-time.Second)
+	// End of the day (23:59:59)
+	endOfDay := startOfDay.AddDate(0, 0, 1).Add(-time.Second)
 
-	startTimestamp := startOfYesterday.Unix()
-	endTimestamp := endOfYesterday.Unix()
+	return startOfDay.Unix(), endOfDay.Unix()
+}
 
-	return startTimestamp, endTimestamp
+// FormatDuration writes a duration rounded to the minute without its zero units, e.g. "1h" or "1h30m".
+func FormatDuration(d time.Duration) string {
+	value := strings.TrimSuffix(d.Round(time.Minute).String(), "0s")
+	if strings.HasSuffix(value, "h0m") {
+		value = strings.TrimSuffix(value, "0m")
+	}
+	return value
 }
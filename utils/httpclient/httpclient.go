@@ -0,0 +1,36 @@
+package httpclient
+
+import (
+	"crypto-analytics/models/constants"
+	"crypto-analytics/pkg/cassette"
+	"crypto-analytics/utils/metrics"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
+)
+
+// New returns the HTTP client shared by the services calling external APIs.
+// Calls to a same host are spaced by API_MIN_INTERVAL and retried with backoff,
+// each attempt being bounded by timeout.
+// Depending on HTTP_CASSETTE_MODE, exchanges are recorded to or replayed from HTTP_CASSETTE_DIR;
+// the cassettes wrap the retries, a replayed exchange is neither delayed nor retried.
+func New(timeout time.Duration) (*http.Client, error) {
+	mode := cassette.Mode(viper.GetString(constants.HTTPCassetteMode))
+	transport, err := cassette.New(mode, viper.GetString(constants.HTTPCassetteDir), &retryTransport{
+		next:           metrics.NewTransport(http.DefaultTransport),
+		attemptTimeout: timeout,
+		minInterval:    viper.GetDuration(constants.APIMinInterval),
+		lastCalls:      make(map[string]time.Time),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if mode != cassette.ModeOff {
+		log.Warn().Str("mode", string(mode)).Str("dir", viper.GetString(constants.HTTPCassetteDir)).Msg("HTTP cassettes enabled")
+	}
+
+	return &http.Client{Transport: transport}, nil
+}
@@ -0,0 +1,140 @@
+package httpclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+func (transport *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	backoff := initialBackoff
+	var lastErr error
+
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		if err := transport.waitTurn(req.Context(), req.URL.Host); err != nil {
+			return nil, err
+		}
+
+		attemptReq, cancel, err := transport.prepareAttempt(req, attempt)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := transport.next.RoundTrip(attemptReq)
+		wait := backoff
+		switch {
+		case err != nil:
+			lastErr = err
+		case isRetryable(resp.StatusCode):
+			lastErr = fmt.Errorf("API request failed with status: %d", resp.StatusCode)
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+				wait = retryAfter
+			}
+			drainAndClose(resp)
+		default:
+			resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+			return resp, nil
+		}
+		cancel()
+
+		if attempt == maxAttempts {
+			break
+		}
+
+		log.Warn().Err(lastErr).Str("url", req.URL.String()).Int("attempt", attempt).Dur("wait", wait).Msg("Retrying HTTP request")
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+		backoff = min(backoff*backoffMultiple, maxBackoff)
+	}
+
+	return nil, fmt.Errorf("giving up after %d attempts: %w", maxAttempts, lastErr)
+}
+
+// waitTurn blocks until minInterval has elapsed since the previous call to host.
+func (transport *retryTransport) waitTurn(ctx context.Context, host string) error {
+	transport.mutex.Lock()
+	next := transport.lastCalls[host].Add(transport.minInterval)
+	now := time.Now()
+	if next.Before(now) {
+		next = now
+	}
+	transport.lastCalls[host] = next
+	transport.mutex.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(time.Until(next)):
+		return nil
+	}
+}
+
+// prepareAttempt clones the request with its own timeout and a fresh body.
+func (transport *retryTransport) prepareAttempt(req *http.Request, attempt int) (*http.Request, context.CancelFunc, error) {
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if transport.attemptTimeout > 0 {
+		ctx, cancel = context.WithTimeout(req.Context(), transport.attemptTimeout)
+	} else {
+		ctx, cancel = context.WithCancel(req.Context())
+	}
+
+	attemptReq := req.Clone(ctx)
+	if attempt > 1 && req.Body != nil && req.Body != http.NoBody {
+		if req.GetBody == nil {
+			cancel()
+			return nil, nil, fmt.Errorf("cannot retry request with a non-replayable body: %s", req.URL)
+		}
+		body, err := req.GetBody()
+		if err != nil {
+			cancel()
+			return nil, nil, err
+		}
+		attemptReq.Body = body
+	}
+
+	return attemptReq, cancel, nil
+}
+
+func isRetryable(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError
+}
+
+// parseRetryAfter accepts both delay-seconds and HTTP-date forms.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return min(time.Duration(seconds)*time.Second, maxBackoff), true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return min(max(time.Until(date), 0), maxBackoff), true
+	}
+	return 0, false
+}
+
+func drainAndClose(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+}
+
+// cancelOnClose releases the attempt context once the caller is done with the body.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (body *cancelOnClose) Close() error {
+	err := body.ReadCloser.Close()
+	body.cancel()
+	return err
+}
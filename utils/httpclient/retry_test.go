@@ -0,0 +1,170 @@
+package httpclient
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestTransport(attemptTimeout time.Duration) *retryTransport {
+	return &retryTransport{
+		next:           http.DefaultTransport,
+		attemptTimeout: attemptTimeout,
+		lastCalls:      make(map[string]time.Time),
+	}
+}
+
+// failingServer answers the first failures calls with the given status and Retry-After, then echoes the request body.
+func failingServer(t *testing.T, failures int32, status int, retryAfter string) (*httptest.Server, *atomic.Int32, *[]string) {
+	t.Helper()
+
+	var calls atomic.Int32
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if calls.Add(1) <= failures {
+			if retryAfter != "" {
+				w.Header().Set("Retry-After", retryAfter)
+			}
+			w.WriteHeader(status)
+			return
+		}
+		w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls, &bodies
+}
+
+func TestRoundTripHonoursRetryAfter(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		retryAfter string
+	}{
+		{"seconds", http.StatusTooManyRequests, "0"},
+		{"HTTP date", http.StatusServiceUnavailable, time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server, calls, _ := failingServer(t, 2, test.status, test.retryAfter)
+			req, _ := http.NewRequest(http.MethodPost, server.URL, strings.NewReader("payload"))
+
+			start := time.Now()
+			resp, err := newTestTransport(time.Second).RoundTrip(req)
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			defer resp.Body.Close()
+
+			// The backoff would have waited 1s then 2s.
+			if elapsed := time.Since(start); elapsed >= initialBackoff {
+				t.Errorf("retried after %v, Retry-After ignored", elapsed)
+			}
+			if calls.Load() != 3 {
+				t.Errorf("%d calls, want 3", calls.Load())
+			}
+		})
+	}
+}
+
+func TestRoundTripReplaysBody(t *testing.T) {
+	server, _, bodies := failingServer(t, 1, http.StatusBadGateway, "0")
+	req, _ := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(`{"handle":"IExecRLC"}`))
+
+	resp, err := newTestTransport(time.Second).RoundTrip(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatalf("cannot read the body once returned: %v", err)
+	}
+
+	if string(body) != `{"handle":"IExecRLC"}` {
+		t.Errorf("response body = %q", body)
+	}
+	if len(*bodies) != 2 || (*bodies)[0] != (*bodies)[1] {
+		t.Errorf("bodies received = %q, want the payload twice", *bodies)
+	}
+}
+
+func TestRoundTripRefusesNonReplayableBody(t *testing.T) {
+	server, calls, _ := failingServer(t, 1, http.StatusServiceUnavailable, "0")
+	req, _ := http.NewRequest(http.MethodPost, server.URL, io.NopCloser(strings.NewReader("payload")))
+
+	_, err := newTestTransport(time.Second).RoundTrip(req)
+	if err == nil || !strings.Contains(err.Error(), "non-replayable body") {
+		t.Errorf("error = %v, want a non-replayable body error", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("%d calls, want 1", calls.Load())
+	}
+}
+
+func TestRoundTripGivesUp(t *testing.T) {
+	server, calls, _ := failingServer(t, maxAttempts, http.StatusInternalServerError, "0")
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+
+	_, err := newTestTransport(time.Second).RoundTrip(req)
+	if err == nil || !strings.Contains(err.Error(), "giving up after 5 attempts") {
+		t.Errorf("error = %v, want giving up", err)
+	}
+	if calls.Load() != maxAttempts {
+		t.Errorf("%d calls, want %d", calls.Load(), maxAttempts)
+	}
+}
+
+func TestRoundTripBoundsEachAttempt(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			time.Sleep(200 * time.Millisecond)
+		}
+		io.WriteString(w, "ok")
+	}))
+	t.Cleanup(server.Close)
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+
+	resp, err := newTestTransport(50 * time.Millisecond).RoundTrip(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if calls.Load() != 2 {
+		t.Errorf("%d calls, want the slow attempt to time out and a second one", calls.Load())
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{"", 0, false},
+		{"12", 12 * time.Second, true},
+		{"3600", maxBackoff, true},
+		{"-1", 0, false},
+		{"soon", 0, false},
+		{time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), 0, true},
+		{time.Now().Add(2 * time.Hour).UTC().Format(http.TimeFormat), maxBackoff, true},
+	}
+	for _, test := range tests {
+		got, ok := parseRetryAfter(test.value)
+		if got != test.want || ok != test.ok {
+			t.Errorf("parseRetryAfter(%q) = (%v, %t), want (%v, %t)", test.value, got, ok, test.want, test.ok)
+		}
+	}
+
+	got, ok := parseRetryAfter(time.Now().Add(30 * time.Second).UTC().Format(http.TimeFormat))
+	if !ok || got <= 28*time.Second || got > 30*time.Second {
+		t.Errorf("parseRetryAfter(in 30s) = (%v, %t), want about 30s", got, ok)
+	}
+}
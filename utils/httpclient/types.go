@@ -0,0 +1,24 @@
+package httpclient
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	maxAttempts     = 5
+	initialBackoff  = 1 * time.Second
+	maxBackoff      = 1 * time.Minute
+	backoffMultiple = 2
+)
+
+// retryTransport spaces out requests per host, retries on network errors,
+// 429 and 5xx responses with exponential backoff and honours Retry-After.
+type retryTransport struct {
+	next           http.RoundTripper
+	attemptTimeout time.Duration
+	minInterval    time.Duration
+	lastCalls      map[string]time.Time
+	mutex          sync.Mutex
+}
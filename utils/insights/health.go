@@ -0,0 +1,150 @@
+package insights
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	HealthStatusUp       = "up"
+	HealthStatusDegraded = "degraded"
+	HealthStatusDown     = "down"
+)
+
+var (
+	ErrNeverSucceeded = errors.New("no success since startup")
+	ErrNotReady       = errors.New("not ready")
+)
+
+// Check tells when the checked component last succeeded; a non-nil error marks it unhealthy.
+type Check func() (time.Time, error)
+
+type CheckResult struct {
+	Name        string     `json:"name"`
+	Critical    bool       `json:"critical"`
+	Healthy     bool       `json:"healthy"`
+	Error       string     `json:"error,omitempty"`
+	LastSuccess *time.Time `json:"lastSuccess,omitempty"`
+}
+
+type HealthReport struct {
+	Status string        `json:"status"`
+	Checks []CheckResult `json:"checks"`
+}
+
+type namedCheck struct {
+	name     string
+	critical bool
+	check    Check
+}
+
+// Heartbeat remembers when an operation last succeeded.
+type Heartbeat struct {
+	mutex sync.RWMutex
+	last  time.Time
+}
+
+func (heartbeat *Heartbeat) Beat() {
+	heartbeat.mutex.Lock()
+	defer heartbeat.mutex.Unlock()
+	heartbeat.last = time.Now()
+}
+
+func (heartbeat *Heartbeat) Last() time.Time {
+	heartbeat.mutex.RLock()
+	defer heartbeat.mutex.RUnlock()
+	return heartbeat.last
+}
+
+// Check fails when the last beat is older than maxAge.
+func (heartbeat *Heartbeat) Check(maxAge time.Duration) Check {
+	return func() (time.Time, error) {
+		last := heartbeat.Last()
+		if last.IsZero() {
+			return last, ErrNeverSucceeded
+		}
+		if age := time.Since(last); age > maxAge {
+			return last, fmt.Errorf("last success %s ago, more than %s", age.Round(time.Second), maxAge)
+		}
+		return last, nil
+	}
+}
+
+// ReadyCheck adapts an IsReadyFunc, reporting the check time as last success.
+func ReadyCheck(isReadyFunc IsReadyFunc) Check {
+	return func() (time.Time, error) {
+		if !isReadyFunc() {
+			return time.Time{}, ErrNotReady
+		}
+		return time.Now(), nil
+	}
+}
+
+// AddCheck registers a health check; failing critical checks make the application not ready.
+func (probes *probes) AddCheck(name string, critical bool, check Check) {
+	probes.checksMutex.Lock()
+	defer probes.checksMutex.Unlock()
+	probes.checks = append(probes.checks, namedCheck{name: name, critical: critical, check: check})
+}
+
+func (probes *probes) Health() HealthReport {
+	probes.checksMutex.RLock()
+	checks := append([]namedCheck(nil), probes.checks...)
+	probes.checksMutex.RUnlock()
+
+	report := HealthReport{Status: HealthStatusUp, Checks: make([]CheckResult, 0, len(checks))}
+	for _, check := range checks {
+		result := runCheck(check)
+		if !result.Healthy {
+			if check.critical {
+				report.Status = HealthStatusDown
+			} else if report.Status == HealthStatusUp {
+				report.Status = HealthStatusDegraded
+			}
+		}
+		report.Checks = append(report.Checks, result)
+	}
+
+	return report
+}
+
+func (probes *probes) health(w http.ResponseWriter, _ *http.Request) {
+	report := probes.Health()
+	status := http.StatusOK
+	if report.Status == HealthStatusDown {
+		status = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		log.Error().Err(err).Msgf("Cannot encode health report")
+	}
+}
+
+//nolint:nonamedreturns // Same as checkReadiness, a crashing check is reported as unhealthy.
+func runCheck(check namedCheck) (result CheckResult) {
+	result = CheckResult{Name: check.name, Critical: check.critical}
+	defer func() {
+		if err := recover(); err != nil {
+			result.Healthy = false
+			result.Error = fmt.Sprintf("crash while checking: %v", err)
+		}
+	}()
+
+	lastSuccess, err := check.check()
+	if !lastSuccess.IsZero() {
+		result.LastSuccess = &lastSuccess
+	}
+	result.Healthy = err == nil
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
@@ -0,0 +1,18 @@
+package insights
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+)
+
+// JSONHandler serves the value returned by getter as JSON.
+func JSONHandler[T any](getter func() T) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(getter()); err != nil {
+			log.Error().Err(err).Msgf("Cannot encode JSON response")
+		}
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"crypto-analytics/models/constants"
 	"fmt"
 	"net/http"
+	"sync"
 
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/viper"
@@ -13,22 +14,30 @@ import (
 type Probes interface {
 	ListenAndServe()
 	Shutdown()
+	Handle(pattern string, handler http.Handler)
+	AddCheck(name string, critical bool, check Check)
+	Health() HealthReport
 }
 
 type probes struct {
 	server       *http.Server
+	mux          *http.ServeMux
 	isReadyFuncs []IsReadyFunc
+	checks       []namedCheck
+	checksMutex  sync.RWMutex
 }
 
 type IsReadyFunc func() bool
 
 func NewProbes(isReadyFuncs ...IsReadyFunc) Probes {
-	impl := probes{
+	impl := &probes{
 		isReadyFuncs: isReadyFuncs,
 	}
 	probesMux := http.NewServeMux()
 	probesMux.HandleFunc("/live", impl.live)
 	probesMux.HandleFunc("/ready", impl.ready)
+	probesMux.HandleFunc("/health", impl.health)
+	impl.mux = probesMux
 
 	impl.server = &http.Server{
 		Addr:              fmt.Sprintf(":%v", viper.GetInt(constants.ProbePort)),
@@ -36,7 +45,7 @@ func NewProbes(isReadyFuncs ...IsReadyFunc) Probes {
 		ReadHeaderTimeout: 0,
 	}
 
-	return &impl
+	return impl
 }
 
 func (probes *probes) ListenAndServe() {
@@ -47,6 +56,11 @@ func (probes *probes) ListenAndServe() {
 	}
 }
 
+// Handle exposes an additional endpoint next to the probes; must be called before ListenAndServe.
+func (probes *probes) Handle(pattern string, handler http.Handler) {
+	probes.mux.Handle(pattern, handler)
+}
+
 func (probes *probes) Shutdown() {
 	if probes.server != nil {
 		if err := probes.server.Shutdown(context.Background()); err != nil {
@@ -65,6 +79,7 @@ func (probes *probes) ready(w http.ResponseWriter, _ *http.Request) {
 	for _, isReadyFunc := range probes.isReadyFuncs {
 		isReady = isReady && checkReadiness(isReadyFunc)
 	}
+	isReady = isReady && probes.Health().Status != HealthStatusDown
 
 	if isReady {
 		w.WriteHeader(http.StatusOK)
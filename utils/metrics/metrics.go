@@ -0,0 +1,111 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-co-op/gocron/v2"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"gorm.io/gorm"
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		jobRuns, jobDuration, jobLastSuccess,
+		httpRequests, httpDuration,
+		rowsSaved,
+		telegramMessages,
+	)
+}
+
+// Handler serves the metrics in the Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// RegisterGauge exposes a value computed at scrape time, such as the subscriber count.
+func RegisterGauge(name string, help string, value func() float64) {
+	registry.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      name,
+		Help:      help,
+	}, value))
+}
+
+// TelegramMessage counts a message sent, or failed to be sent, for a command.
+func TelegramMessage(command string, err error) {
+	telegramMessages.WithLabelValues(command, status(err == nil)).Inc()
+}
+
+// InstrumentDB counts the rows created or updated through gorm by table.
+func InstrumentDB(db *gorm.DB) error {
+	count := func(tx *gorm.DB) {
+		if tx.Error == nil && tx.RowsAffected > 0 && tx.Statement.Table != "" {
+			rowsSaved.WithLabelValues(tx.Statement.Table).Add(float64(tx.RowsAffected))
+		}
+	}
+
+	if err := db.Callback().Create().After("gorm:create").Register("metrics:rows_created", count); err != nil {
+		return err
+	}
+	return db.Callback().Update().After("gorm:update").Register("metrics:rows_updated", count)
+}
+
+// NewJobMonitor records runs, failures and duration of the scheduled jobs.
+func NewJobMonitor() gocron.MonitorStatus {
+	return &jobMonitor{}
+}
+
+type jobMonitor struct{}
+
+func (monitor *jobMonitor) IncrementJob(_ uuid.UUID, _ string, _ []string, _ gocron.JobStatus) {
+	// Runs are counted along their timing in RecordJobTimingWithStatus.
+}
+
+func (monitor *jobMonitor) RecordJobTiming(_, _ time.Time, _ uuid.UUID, _ string, _ []string) {
+	// Superseded by RecordJobTimingWithStatus.
+}
+
+func (monitor *jobMonitor) RecordJobTimingWithStatus(startTime, endTime time.Time, _ uuid.UUID, name string, _ []string, jobStatus gocron.JobStatus, _ error) {
+	succeeded := jobStatus == gocron.Success
+	jobRuns.WithLabelValues(name, status(succeeded)).Inc()
+	jobDuration.WithLabelValues(name, status(succeeded)).Observe(endTime.Sub(startTime).Seconds())
+	if succeeded {
+		jobLastSuccess.WithLabelValues(name).Set(float64(endTime.Unix()))
+	}
+}
+
+// NewTransport measures the latency and status code of each outgoing request.
+func NewTransport(next http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		start := time.Now()
+		resp, err := next.RoundTrip(req)
+
+		code := "error"
+		if err == nil {
+			code = strconv.Itoa(resp.StatusCode)
+		}
+		httpRequests.WithLabelValues(req.URL.Host, req.URL.Path, code).Inc()
+		httpDuration.WithLabelValues(req.URL.Host, req.URL.Path).Observe(time.Since(start).Seconds())
+		return resp, err
+	})
+}
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func status(succeeded bool) string {
+	if succeeded {
+		return StatusSuccess
+	}
+	return StatusFailure
+}
@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	namespace = "crypto_analytics"
+
+	StatusSuccess = "success"
+	StatusFailure = "failure"
+)
+
+var (
+	registry = prometheus.NewRegistry()
+
+	jobRuns = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "job_runs_total",
+		Help:      "Scheduled job runs by job name and status.",
+	}, []string{"job", "status"})
+
+	jobDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "job_duration_seconds",
+		Help:      "Duration of the scheduled jobs.",
+		Buckets:   []float64{0.1, 0.5, 1, 5, 15, 30, 60, 120, 300, 900, 3600},
+	}, []string{"job", "status"})
+
+	jobLastSuccess = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "job_last_success_timestamp_seconds",
+		Help:      "Unix time of the last successful run of each job.",
+	}, []string{"job"})
+
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_client_requests_total",
+		Help:      "Outgoing HTTP requests by host, endpoint and status code.",
+	}, []string{"host", "endpoint", "code"})
+
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_client_request_duration_seconds",
+		Help:      "Latency of the outgoing HTTP requests.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"host", "endpoint"})
+
+	rowsSaved = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rows_saved_total",
+		Help:      "Rows created or updated by table.",
+	}, []string{"table"})
+
+	telegramMessages = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "telegram_messages_total",
+		Help:      "Telegram messages sent by command and status.",
+	}, []string{"command", "status"})
+)